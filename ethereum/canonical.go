@@ -0,0 +1,132 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
+	"github.com/umbracle/fastrlp"
+)
+
+// canonicalContract is a contract deployed at the same address in every chain by a
+// pre-signed transaction that is not replay protected (pre-EIP-155). The transaction
+// is sent by a one-time deployer account, so it only needs funds to pay for the gas.
+type canonicalContract struct {
+	Address ethgo.Address
+
+	// Transaction is the hex encoded pre-signed deployment transaction,
+	// if it is not known it must be provided by the user.
+	Transaction string
+
+	// Deployer is the one-time account that signs the published
+	// deployment transaction.
+	Deployer ethgo.Address
+
+	// Code is the hex encoded runtime code of the contract. It is installed
+	// in development nodes if there is no pre-signed transaction.
+	Code string
+
+	// CodeHash is the hash of the runtime code of the contract
+	CodeHash ethgo.Hash
+}
+
+var canonicalContracts = map[string]*canonicalContract{
+	"deterministic_deployment_proxy": {
+		Address:     ethgo.HexToAddress(deterministicDeploymentProxy),
+		Deployer:    ethgo.HexToAddress("0x3fab184622dc19b6109349b94811493bf2a45362"),
+		Transaction: "0xf8a58085174876e800830186a08080b853604580600e600039806000f350fe7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf31ba02222222222222222222222222222222222222222222222222222222222222222a02222222222222222222222222222222222222222222222222222222222222222",
+	},
+	"multicall3": {
+		Address:  ethgo.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11"),
+		Deployer: ethgo.HexToAddress("0x05f32B3cC3888453ff71B01135B34FF8e41263F2"),
+		Code:     multicall3Code,
+		CodeHash: ethgo.HexToHash("0xd5c15df687b16f2ff992fc8d767b4216323184a2bbc6ee2f9c398c318e770891"),
+	},
+	"createx": {
+		Address:  ethgo.HexToAddress(createXFactory),
+		Deployer: ethgo.HexToAddress("0xeD456e05CaAb11d66C4c797dD6c1D6f9A7F352b5"),
+		Code:     createXCode,
+		CodeHash: ethgo.HexToHash("0xbd8a7ea8cfca7b4e5f5041d7d4b17bc317c5ce42cfbc42066a00cf26b43eb53f"),
+	},
+}
+
+// canonicalContractNames are the canonical contracts in the order they are deployed.
+var canonicalContractNames = []string{"deterministic_deployment_proxy", "multicall3", "createx"}
+
+// presignedTransaction is a decoded pre-signed deployment transaction.
+type presignedTransaction struct {
+	Raw    []byte
+	Txn    *ethgo.Transaction
+	Sender ethgo.Address
+}
+
+// cost returns the maximum amount that the sender pays for the transaction.
+func (p *presignedTransaction) cost() *big.Int {
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(p.Txn.Gas), new(big.Int).SetUint64(p.Txn.GasPrice))
+	if p.Txn.Value != nil {
+		cost.Add(cost, p.Txn.Value)
+	}
+	return cost
+}
+
+// decodePresignedTransaction decodes a pre-signed deployment transaction and
+// checks that it creates the contract at the expected address.
+func decodePresignedTransaction(str string, addr ethgo.Address) (*presignedTransaction, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(str), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %v", err)
+	}
+
+	txn := &ethgo.Transaction{}
+	if err := txn.UnmarshalRLP(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %v", err)
+	}
+	if txn.Type != ethgo.TransactionLegacy || txn.To != nil {
+		return nil, fmt.Errorf("transaction is not a legacy contract creation")
+	}
+
+	// the transaction is valid in every chain since it does not include the chain id
+	v := new(big.Int).SetBytes(txn.V)
+	if !v.IsUint64() || (v.Uint64() != 27 && v.Uint64() != 28) {
+		return nil, fmt.Errorf("transaction is replay protected")
+	}
+
+	sig := make([]byte, 65)
+	copy(sig[32-len(txn.R):32], txn.R)
+	copy(sig[64-len(txn.S):64], txn.S)
+	sig[64] = byte(v.Uint64() - 27)
+
+	sender, err := wallet.Ecrecover(ethgo.Keccak256(signingPayload(txn, nil)), sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover the sender: %v", err)
+	}
+	if created := createAddress(sender, txn.Nonce); created != addr {
+		return nil, fmt.Errorf("transaction deploys to %s instead of %s", created, addr)
+	}
+
+	presigned := &presignedTransaction{
+		Raw:    raw,
+		Txn:    txn,
+		Sender: sender,
+	}
+	return presigned, nil
+}
+
+// createAddress returns the address of a contract created
+// with CREATE (keccak256(rlp([sender, nonce]))[12:]).
+func createAddress(sender ethgo.Address, nonce uint64) ethgo.Address {
+	a := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(a)
+
+	v := a.NewArray()
+	v.Set(a.NewCopyBytes(sender[:]))
+	v.Set(a.NewUint(nonce))
+	hash := ethgo.Keccak256(v.MarshalTo(nil))
+
+	var addr ethgo.Address
+	copy(addr[:], hash[12:])
+	return addr
+}
@@ -0,0 +1,85 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestDecodePresignedTransaction(t *testing.T) {
+	proxy := canonicalContracts["deterministic_deployment_proxy"]
+
+	presigned, err := decodePresignedTransaction(proxy.Transaction, proxy.Address)
+	require.NoError(t, err)
+	require.Equal(t, ethgo.HexToAddress("0x3fab184622dc19b6109349b94811493bf2a45362"), presigned.Sender)
+	require.Equal(t, "10000000000000000", presigned.cost().String())
+
+	// the transaction deploys a different contract
+	_, err = decodePresignedTransaction(proxy.Transaction, ethgo.Address{0x1})
+	require.Error(t, err)
+}
+
+func TestCreateAddress(t *testing.T) {
+	sender := ethgo.HexToAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+
+	require.Equal(t, ethgo.HexToAddress("0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d"), createAddress(sender, 0))
+	require.Equal(t, ethgo.HexToAddress("0x343c43a37d37dff08ae8c4a11544c718abb4fcf8"), createAddress(sender, 1))
+}
+
+func TestCanonicalContracts_Deployer(t *testing.T) {
+	// the contracts are created by the first transaction of their deployers
+	for name, contract := range canonicalContracts {
+		require.Equal(t, contract.Address, createAddress(contract.Deployer, 0), name)
+	}
+}
+
+func TestCanonicalContracts_Code(t *testing.T) {
+	for name, contract := range canonicalContracts {
+		if contract.Transaction != "" {
+			// deployed with the built-in pre-signed transaction
+			_, err := decodePresignedTransaction(contract.Transaction, contract.Address)
+			require.NoError(t, err, name)
+			continue
+		}
+
+		// installed with its runtime code in development nodes
+		code, err := hex.DecodeString(strings.TrimPrefix(contract.Code, "0x"))
+		require.NoError(t, err, name)
+		require.Equal(t, contract.CodeHash, ethgo.BytesToHash(ethgo.Keccak256(code)), name)
+	}
+}
+
+func TestInstallCanonicalContract(t *testing.T) {
+	contract := canonicalContracts["multicall3"]
+
+	var code, installed string
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"hardhat_setCode": func(params []json.RawMessage) (interface{}, error) {
+			if err := json.Unmarshal(params[1], &code); err != nil {
+				return nil, err
+			}
+			if installed != "" {
+				code = installed
+			}
+			return nil, nil
+		},
+		"eth_getCode": func(params []json.RawMessage) (interface{}, error) {
+			return code, nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	// anvil_setCode is not available and it falls back to hardhat_setCode
+	require.NoError(t, installCanonicalContract(clt, contract))
+	require.Equal(t, contract.Code, code)
+
+	// the code installed is not the one of the contract
+	installed = "0x6080"
+	require.Error(t, installCanonicalContract(clt, contract))
+}
@@ -2,126 +2,924 @@ package ethereum
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
 	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
 	"github.com/umbracle/ethgo/wallet"
 )
 
 type client struct {
 	httpClient *jsonrpc.Client
-	nonceLock  sync.Mutex
+	nonces     *nonceManager
+
+	// pool forwards the requests to the endpoints if
+	// more than one host is configured.
+	pool *endpointPool
+
+	// confirmations is the default number of blocks on top of the
+	// one that includes a transaction required to consider it final.
+	confirmations uint64
+
+	// signers are the named signers configured in the provider.
+	signers map[string]transactionSigner
+
+	// chainID is the cached chain id of the node
+	chainID     *big.Int
+	chainIDLock sync.Mutex
+}
+
+type clientConfig struct {
+	// Hosts are the endpoints of the node ordered by priority.
+	Hosts []string
+
+	// HealthCheckInterval is the frequency at which the heads of
+	// the endpoints are compared. Only used with multiple hosts.
+	HealthCheckInterval time.Duration
+
+	// MaxBlockLag is the number of blocks an endpoint can lag
+	// behind the others before it is skipped.
+	MaxBlockLag uint64
+
+	// Headers are static headers sent with every request.
+	Headers map[string]string
+
+	// BasicAuthUsername and BasicAuthPassword are the credentials
+	// for endpoints that use http basic authentication.
+	BasicAuthUsername string
+	BasicAuthPassword string
+
+	// BearerToken is sent in the Authorization header if set.
+	BearerToken string
+
+	// CAFile is a PEM bundle used to verify the endpoints instead
+	// of the system certificates.
+	CAFile string
+
+	// ClientCertFile and ClientKeyFile are the PEM encoded client
+	// certificate and key used for mTLS.
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// ProxyURL is the http proxy used to reach the endpoints.
+	ProxyURL string
+
+	// RequestTimeout is the timeout of each jsonrpc request.
+	RequestTimeout time.Duration
+}
+
+// hasTransportOptions returns true if any of the http transport options
+// (tls, proxy or timeout) is set. The headers do not need a custom transport.
+func (c *clientConfig) hasTransportOptions() bool {
+	return c.CAFile != "" || c.ClientCertFile != "" || c.ProxyURL != "" || c.RequestTimeout != 0
+}
+
+// headers returns the static headers including the authentication ones.
+func (c *clientConfig) headers() map[string]string {
+	headers := map[string]string{}
+	for k, v := range c.Headers {
+		headers[k] = v
+	}
+	if c.BasicAuthUsername != "" {
+		auth := base64.StdEncoding.EncodeToString([]byte(c.BasicAuthUsername + ":" + c.BasicAuthPassword))
+		headers["Authorization"] = "Basic " + auth
+	}
+	if c.BearerToken != "" {
+		headers["Authorization"] = "Bearer " + c.BearerToken
+	}
+	return headers
+}
+
+// httpClient builds the http client with the tls, proxy and timeout options.
+func (c *clientConfig) httpClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+
+	if c.CAFile != "" {
+		data, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in ca file '%s'", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy url: %v", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   c.RequestTimeout,
+	}
+	return client, nil
 }
 
 func newClient(host string) (*client, error) {
-	if host == "" {
-		host = defaultHost
+	return newClientWithConfig(&clientConfig{Hosts: []string{host}})
+}
+
+func newClientWithConfig(config *clientConfig) (*client, error) {
+	hosts := config.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{defaultHost}
 	}
-	httpClient, err := jsonrpc.NewClient(host)
-	if err != nil {
-		return nil, err
+	for i, host := range hosts {
+		if host == "" {
+			hosts[i] = defaultHost
+		}
 	}
 
 	clt := &client{
-		httpClient: httpClient,
+		nonces: newNonceManager(),
+	}
+
+	host := hosts[0]
+	var opts []jsonrpc.ConfigOption
+
+	if !isHTTPHost(host) {
+		// websocket and ipc endpoints are used directly by the
+		// jsonrpc client which only supports static headers.
+		if len(hosts) > 1 {
+			return nil, fmt.Errorf("multiple hosts are only supported for http endpoints")
+		}
+		if config.hasTransportOptions() {
+			return nil, fmt.Errorf("tls, proxy and timeout options are only supported for http endpoints")
+		}
+		opts = append(opts, jsonrpc.WithHeaders(config.headers()))
+
+	} else if len(hosts) == 1 && !config.hasTransportOptions() {
+		// a single endpoint with static headers does not need the pool
+		opts = append(opts, jsonrpc.WithHeaders(config.headers()))
+
+	} else {
+		config.Hosts = hosts
+
+		pool, err := newEndpointPool(config)
+		if err != nil {
+			return nil, err
+		}
+		clt.pool = pool
+		host = pool.URL()
+	}
+
+	httpClient, err := jsonrpc.NewClient(host, opts...)
+	if err != nil {
+		return nil, err
 	}
+	clt.httpClient = httpClient
+
 	return clt, nil
 }
 
+// isHTTPHost returns false for websocket urls and ipc paths.
+func isHTTPHost(host string) bool {
+	return strings.HasPrefix(host, "http://") || strings.HasPrefix(host, "https://")
+}
+
+func (c *client) Close() error {
+	if c.pool != nil {
+		c.pool.Close()
+	}
+	return c.httpClient.Close()
+}
+
 func (c *client) Http() *jsonrpc.Eth {
 	return c.httpClient.Eth()
 }
 
+// getChainID returns the chain id of the node. It is only
+// queried once since it does not change for an endpoint.
+func (c *client) getChainID() (*big.Int, error) {
+	c.chainIDLock.Lock()
+	defer c.chainIDLock.Unlock()
+
+	if c.chainID == nil {
+		chainID, err := c.httpClient.Eth().ChainID()
+		if err != nil {
+			return nil, err
+		}
+		c.chainID = chainID
+	}
+	return new(big.Int).Set(c.chainID), nil
+}
+
+// transactionSigner signs the transactions sent by the client. It returns
+// the signed transaction encoded as it is broadcast to the network. The
+// external signers stop waiting for the signature when the context is done.
+type transactionSigner interface {
+	Address() ethgo.Address
+	SignTransaction(ctx context.Context, txn *ethgo.Transaction, chainID *big.Int) ([]byte, error)
+}
+
+// keySigner signs the transactions with a local private key.
+type keySigner struct {
+	key ethgo.Key
+}
+
+func newKeySigner(priv []byte) (*keySigner, error) {
+	key, err := wallet.NewWalletFromPrivKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return &keySigner{key: key}, nil
+}
+
+func (k *keySigner) Address() ethgo.Address {
+	return k.key.Address()
+}
+
+func (k *keySigner) SignTransaction(ctx context.Context, txn *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	signed, err := wallet.NewEIP155Signer(chainID.Uint64()).SignTx(txn, k.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalRLPTo(nil)
+}
+
+// nodeSigner sends the transactions unsigned with eth_sendTransaction to be
+// signed by the node with one of its unlocked accounts (i.e. development nodes).
+type nodeSigner struct {
+	address ethgo.Address
+
+	// impersonate impersonates the account before sending the transaction
+	// (anvil_impersonateAccount) so that the node does not need its key.
+	impersonate bool
+}
+
+func (n *nodeSigner) Address() ethgo.Address {
+	return n.address
+}
+
+func (n *nodeSigner) SignTransaction(ctx context.Context, txn *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	return nil, fmt.Errorf("the transactions from %s are signed by the node", n.address)
+}
+
 type transaction struct {
 	To       *ethgo.Address
 	Input    []byte
 	Value    *big.Int
-	Signer   []byte
+	Signer   transactionSigner
 	GasLimit uint64
+
+	// GasPrice is the gas price of a legacy or access list transaction. If
+	// not set, it is filled in with a suggestion from the node.
+	GasPrice uint64
+
+	// Type is the envelope of the transaction. Legacy and access list (EIP-2930)
+	// transactions are priced with the gas price of the node while dynamic fee ones (EIP-1559)
+	// use the max fee and max priority fee values. If any of the fees is
+	// not set, it is filled in with a suggestion from the node.
+	Type                 ethgo.TransactionType
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+
+	// AccessList are the addresses and storage keys accessed by the transaction
+	// (EIP-2930). If AutoAccessList is set, the list is generated by the node and
+	// only used if it lowers the gas of the transaction.
+	AccessList     ethgo.AccessList
+	AutoAccessList bool
+
+	// Abi is used to decode the custom errors of the contract
+	// if the transaction reverts.
+	Abi *abi.ABI
+
+	// Confirmations is the number of blocks to wait on top of the
+	// block that includes the transaction.
+	Confirmations uint64
+
+	// FeeBump replaces the transaction with one with higher fees
+	// if it is not included in a block after some time.
+	FeeBump *feeBump
+
+	// Hashes are the hashes of all the transactions broadcast, the
+	// original one and its replacements. It is set by sendTransaction.
+	Hashes []ethgo.Hash
 }
 
-func (c *client) sendTransaction(txn *transaction) (ethgo.Hash, *ethgo.Receipt, error) {
+// receiptPollInterval is the frequency at which the client
+// checks if a sent transaction has been included in a block.
+const receiptPollInterval = 100 * time.Millisecond
+
+// revertError is returned when a transaction reverts, either during gas
+// estimation or once it is included in a block.
+type revertError struct {
+	// Hash is the hash of the reverted transaction. It is empty
+	// if the transaction reverted before being sent.
+	Hash ethgo.Hash
+
+	// Reason is the decoded revert reason, if any.
+	Reason string
+}
+
+func (r *revertError) Error() string {
+	msg := "execution reverted"
+	if r.Hash != (ethgo.Hash{}) {
+		msg = fmt.Sprintf("transaction %s reverted", r.Hash)
+	}
+	if r.Reason != "" {
+		msg += ": " + r.Reason
+	}
+	return msg
+}
+
+func (c *client) sendTransaction(ctx context.Context, txn *transaction) (ethgo.Hash, *ethgo.Receipt, error) {
 	if txn.Signer == nil {
 		return ethgo.Hash{}, nil, fmt.Errorf("signer not found")
 	}
-	key, err := wallet.NewWalletFromPrivKey(txn.Signer)
+	from := txn.Signer.Address()
+
+	chainID, err := c.getChainID()
 	if err != nil {
 		return ethgo.Hash{}, nil, err
 	}
 
-	from := key.Address()
+	gasPrice := txn.GasPrice
+	switch txn.Type {
+	case ethgo.TransactionLegacy, ethgo.TransactionAccessList:
+		if gasPrice == 0 {
+			if gasPrice, err = c.httpClient.Eth().GasPrice(); err != nil {
+				return ethgo.Hash{}, nil, fmt.Errorf("failed to get gas price: %v", err)
+			}
+		}
+	case ethgo.TransactionDynamicFee:
+		if err := c.fillDynamicFees(txn); err != nil {
+			return ethgo.Hash{}, nil, err
+		}
+	default:
+		return ethgo.Hash{}, nil, fmt.Errorf("transaction type %d not supported", txn.Type)
+	}
 
-	chainID, err := c.httpClient.Eth().ChainID()
+	estimateGas := txn.GasLimit == 0
+	if estimateGas {
+		args := callArgs(from, txn)
+		if gasPrice != 0 {
+			args["gasPrice"] = fmt.Sprintf("0x%x", gasPrice)
+		}
+		txn.GasLimit, err = c.estimateGas(args)
+		if err != nil {
+			if data, ok := revertData(err); ok {
+				err = &revertError{Reason: decodeRevertReason(data, txn.Abi)}
+			}
+			return ethgo.Hash{}, nil, fmt.Errorf("gas estimation failed: %w", err)
+		}
+	}
+	if txn.AutoAccessList {
+		gas, err := c.fillAccessList(from, txn)
+		if err != nil {
+			return ethgo.Hash{}, nil, err
+		}
+		if gas != 0 && estimateGas {
+			txn.GasLimit = gas
+		}
+	}
+
+	// the nonce of the signer is only locked while the transaction is signed
+	// and broadcast, the receipt is awaited after it is released.
+	var hash ethgo.Hash
+	var ethTxn *ethgo.Transaction
+	nonceFn := func() (uint64, error) {
+		return c.httpClient.Eth().GetNonce(from, ethgo.Pending)
+	}
+	err = c.nonces.send(from, nonceFn, func(nonce uint64) error {
+		ethTxn = &ethgo.Transaction{
+			Type:     txn.Type,
+			Input:    txn.Input,
+			To:       txn.To,
+			Value:    txn.Value,
+			Gas:      txn.GasLimit,
+			GasPrice: gasPrice,
+			Nonce:    nonce,
+		}
+		if txn.Type != ethgo.TransactionLegacy {
+			ethTxn.ChainID = chainID
+			ethTxn.AccessList = txn.AccessList
+		}
+		if txn.Type == ethgo.TransactionDynamicFee {
+			ethTxn.MaxFeePerGas = txn.MaxFeePerGas
+			ethTxn.MaxPriorityFeePerGas = txn.MaxPriorityFeePerGas
+		}
+
+		var err error
+		hash, err = c.signAndSend(ctx, txn.Signer, chainID, ethTxn)
+		return err
+	})
 	if err != nil {
 		return ethgo.Hash{}, nil, err
 	}
+	txn.Hashes = []ethgo.Hash{hash}
+
+	var receipt *ethgo.Receipt
+	if txn.FeeBump != nil {
+		hash, receipt, err = c.waitForReceiptWithFeeBump(ctx, chainID, ethTxn, txn)
+	} else {
+		receipt, err = c.waitForReceipt(ctx, hash)
+	}
+	if err != nil {
+		return hash, nil, err
+	}
+	if receipt.Status != 1 {
+		msg := &ethgo.CallMsg{From: from, To: txn.To, Data: txn.Input, Value: txn.Value, Gas: new(big.Int).SetUint64(txn.GasLimit)}
+		return hash, receipt, &revertError{Hash: hash, Reason: c.replayRevertReason(msg, receipt.BlockNumber, txn.Abi)}
+	}
+	if txn.Confirmations != 0 {
+		if receipt, err = c.waitForConfirmations(ctx, hash, receipt, txn.Confirmations); err != nil {
+			return hash, nil, err
+		}
+	}
+	return hash, receipt, nil
+}
+
+// signAndSend signs the transaction with the signer and broadcasts it.
+func (c *client) signAndSend(ctx context.Context, signer transactionSigner, chainID *big.Int, ethTxn *ethgo.Transaction) (ethgo.Hash, error) {
+	if node, ok := signer.(*nodeSigner); ok {
+		return c.sendUnsignedTransaction(node, chainID, ethTxn)
+	}
 
-	gasPrice, err := c.httpClient.Eth().GasPrice()
+	raw, err := signer.SignTransaction(ctx, ethTxn, chainID)
 	if err != nil {
-		return ethgo.Hash{}, nil, fmt.Errorf("failed to get gas price: %v", err)
+		return ethgo.Hash{}, fmt.Errorf("failed to sign transaction: %v", err)
+	}
+	hash, err := c.httpClient.Eth().SendRawTransaction(raw)
+	if err != nil {
+		if !isAlreadyKnown(err) {
+			return ethgo.Hash{}, err
+		}
+		// the transaction was broadcast by a previous attempt
+		// (i.e. the request failed over to another endpoint)
+		hash = ethgo.BytesToHash(ethgo.Keccak256(raw))
 	}
+	return hash, nil
+}
 
-	if txn.GasLimit == 0 {
-		msg := &ethgo.CallMsg{From: from, To: txn.To, Data: txn.Input, GasPrice: gasPrice, Value: txn.Value}
-		txn.GasLimit, err = c.httpClient.Eth().EstimateGas(msg)
-		if err != nil {
-			return ethgo.Hash{}, nil, fmt.Errorf("gas estimation failed: %v", err)
+// isAlreadyKnown returns true if the node rejected a
+// transaction because it is already in its pool.
+func isAlreadyKnown(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already known")
+}
+
+// sendRawTransaction broadcasts a transaction signed elsewhere and waits for its
+// receipt. It is used for the pre-signed transactions that are not replay protected
+// (pre-EIP-155), which some nodes only accept if explicitly allowed.
+func (c *client) sendRawTransaction(ctx context.Context, raw []byte) (ethgo.Hash, *ethgo.Receipt, error) {
+	hash, err := c.httpClient.Eth().SendRawTransaction(raw)
+	if err != nil {
+		msg := strings.ToLower(err.Error())
+		switch {
+		case isAlreadyKnown(err):
+			// the transaction is already in the pool
+			hash = ethgo.BytesToHash(ethgo.Keccak256(raw))
+		case strings.Contains(msg, "replay-protected") || strings.Contains(msg, "replay protected"):
+			return ethgo.Hash{}, nil, fmt.Errorf("%v: the node must allow transactions that are not replay protected (i.e. geth --rpc.allow-unprotected-txs)", err)
+		default:
+			return ethgo.Hash{}, nil, err
 		}
 	}
 
-	c.nonceLock.Lock()
-	nonce, err := c.httpClient.Eth().GetNonce(key.Address(), ethgo.Pending)
+	receipt, err := c.waitForReceipt(ctx, hash)
 	if err != nil {
-		defer c.nonceLock.Unlock()
-		return ethgo.Hash{}, nil, err
+		return hash, nil, err
+	}
+	if receipt.Status != 1 {
+		return hash, receipt, &revertError{Hash: hash}
 	}
+	return hash, receipt, nil
+}
+
+// sendUnsignedTransaction sends the transaction with eth_sendTransaction
+// to be signed by the node with the account of the signer.
+func (c *client) sendUnsignedTransaction(signer *nodeSigner, chainID *big.Int, ethTxn *ethgo.Transaction) (ethgo.Hash, error) {
+	if signer.impersonate {
+		var out interface{}
+		if err := c.httpClient.Call("anvil_impersonateAccount", &out, signer.address); err != nil {
+			return ethgo.Hash{}, fmt.Errorf("failed to impersonate account %s: %v", signer.address, err)
+		}
+	}
+
+	var hash ethgo.Hash
+	if err := c.httpClient.Call("eth_sendTransaction", &hash, signTransactionArgs(signer.address, ethTxn, chainID)); err != nil {
+		return ethgo.Hash{}, err
+	}
+	return hash, nil
+}
+
+// setCode sets the code of the account in a development
+// node (anvil_setCode or hardhat_setCode).
+func (c *client) setCode(addr ethgo.Address, code string) error {
+	var out interface{}
+	err := c.httpClient.Call("anvil_setCode", &out, addr, code)
+	if err == nil {
+		return nil
+	}
+	if err := c.httpClient.Call("hardhat_setCode", &out, addr, code); err == nil {
+		return nil
+	}
+	return fmt.Errorf("failed to set the code of %s: %v", addr, err)
+}
+
+// callArgs returns the call object of eth_call, eth_estimateGas and
+// eth_createAccessList for the transaction sent from the address.
+func callArgs(from ethgo.Address, txn *transaction) map[string]interface{} {
+	args := map[string]interface{}{
+		"from": from,
+	}
+	if txn.To != nil {
+		args["to"] = txn.To
+	}
+	if len(txn.Input) != 0 {
+		args["data"] = "0x" + hex.EncodeToString(txn.Input)
+	}
+	if txn.Value != nil {
+		args["value"] = fmt.Sprintf("0x%x", txn.Value)
+	}
+	if len(txn.AccessList) != 0 {
+		args["accessList"] = txn.AccessList
+	}
+	return args
+}
 
-	ethTxn := &ethgo.Transaction{
-		Input:    txn.Input,
-		To:       txn.To,
-		Value:    txn.Value,
-		Gas:      txn.GasLimit,
-		GasPrice: gasPrice,
-		Nonce:    nonce,
+func (c *client) estimateGas(args map[string]interface{}) (uint64, error) {
+	var out string
+	if err := c.httpClient.Call("eth_estimateGas", &out, args); err != nil {
+		return 0, err
 	}
+	return strconv.ParseUint(strings.TrimPrefix(out, "0x"), 16, 64)
+}
 
-	signer := wallet.NewEIP155Signer(chainID.Uint64())
-	ethTxn, err = signer.SignTx(ethTxn, key)
+// fillAccessList generates the access list of the transaction with
+// eth_createAccessList and only sets it if it lowers the gas estimation.
+// It returns the estimated gas with the access list if it is used.
+func (c *client) fillAccessList(from ethgo.Address, txn *transaction) (uint64, error) {
+	args := callArgs(from, txn)
+
+	var out struct {
+		AccessList ethgo.AccessList `json:"accessList"`
+		Error      string           `json:"error"`
+	}
+	if err := c.httpClient.Call("eth_createAccessList", &out, args, "latest"); err != nil {
+		return 0, fmt.Errorf("failed to create access list: %v", err)
+	}
+	if out.Error != "" {
+		return 0, fmt.Errorf("failed to create access list: %s", out.Error)
+	}
+	if len(out.AccessList) == 0 {
+		return 0, nil
+	}
+	for i := range out.AccessList {
+		if out.AccessList[i].Storage == nil {
+			out.AccessList[i].Storage = []ethgo.Hash{}
+		}
+	}
+
+	gas, err := c.estimateGas(args)
 	if err != nil {
-		defer c.nonceLock.Unlock()
-		return ethgo.Hash{}, nil, err
+		return 0, fmt.Errorf("gas estimation failed: %v", err)
 	}
+	args["accessList"] = out.AccessList
 
-	raw, _ := ethTxn.MarshalRLPTo(nil)
-	hash, err := c.httpClient.Eth().SendRawTransaction(raw)
+	gasWithList, err := c.estimateGas(args)
 	if err != nil {
-		defer c.nonceLock.Unlock()
-		return ethgo.Hash{}, nil, err
+		return 0, fmt.Errorf("gas estimation failed: %v", err)
+	}
+	if gasWithList >= gas {
+		return 0, nil
 	}
+	txn.AccessList = out.AccessList
+	return gasWithList, nil
+}
 
-	c.nonceLock.Unlock()
+// simulateTransaction runs the transaction against the latest block without
+// sending it and returns the estimated gas and fee.
+func (c *client) simulateTransaction(txn *transaction) (uint64, *big.Int, error) {
+	if txn.Signer == nil {
+		return 0, nil, fmt.Errorf("signer not found")
+	}
+	from := txn.Signer.Address()
+
+	msg := &ethgo.CallMsg{From: from, To: txn.To, Data: txn.Input, Value: txn.Value}
+	if _, err := c.httpClient.Eth().Call(msg, ethgo.Latest); err != nil {
+		if data, ok := revertData(err); ok {
+			err = &revertError{Reason: decodeRevertReason(data, txn.Abi)}
+		}
+		return 0, nil, fmt.Errorf("simulation failed: %w", err)
+	}
+	gas, err := c.estimateGas(callArgs(from, txn))
+	if err != nil {
+		return 0, nil, fmt.Errorf("gas estimation failed: %v", err)
+	}
+
+	// the price paid per unit of gas is the gas price for legacy and access list
+	// transactions, and the base fee plus the tip (up to the max fee) for dynamic fee ones.
+	var price *big.Int
+	switch txn.Type {
+	case ethgo.TransactionLegacy, ethgo.TransactionAccessList:
+		gasPrice, err := c.httpClient.Eth().GasPrice()
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to get gas price: %v", err)
+		}
+		price = new(big.Int).SetUint64(gasPrice)
+	case ethgo.TransactionDynamicFee:
+		if err := c.fillDynamicFees(txn); err != nil {
+			return 0, nil, err
+		}
+		baseFee, err := c.baseFee()
+		if err != nil {
+			return 0, nil, err
+		}
+		price = new(big.Int).Add(baseFee, txn.MaxPriorityFeePerGas)
+		if price.Cmp(txn.MaxFeePerGas) > 0 {
+			price = txn.MaxFeePerGas
+		}
+	default:
+		return 0, nil, fmt.Errorf("transaction type %d not supported", txn.Type)
+	}
+
+	fee := new(big.Int).Mul(price, new(big.Int).SetUint64(gas))
+	return gas, fee, nil
+}
+
+// waitForConfirmations waits until there are 'num' blocks on top of the block
+// that includes the transaction. If the transaction is reorged out in the meantime,
+// it waits for it to be included again and starts counting from the new block.
+func (c *client) waitForConfirmations(ctx context.Context, hash ethgo.Hash, receipt *ethgo.Receipt, num uint64) (*ethgo.Receipt, error) {
+	heads, stop := c.watchHeads(receiptPollInterval)
+	defer stop()
 
-	tt := time.NewTimer(15 * time.Second)
 	for {
+		latest, err := c.httpClient.Eth().BlockNumber()
+		if err == nil && latest >= receipt.BlockNumber+num {
+			// make sure that the transaction is still in the canonical
+			// chain before returning the receipt
+			current, err := c.canonicalReceipt(hash)
+			if err != nil {
+				return nil, err
+			}
+			if current != nil && current.BlockHash == receipt.BlockHash {
+				return current, nil
+			}
+
+			// the node may still return the receipt of the reorged out block,
+			// wait for a new head before checking the receipt again
+			select {
+			case <-heads:
+			case <-ctx.Done():
+				return nil, fmt.Errorf("transaction %s not confirmed after %d blocks: %w", hash, num, ctx.Err())
+			}
+			if receipt, err = c.waitForReceipt(ctx, hash); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		select {
-		case <-time.After(100 * time.Millisecond):
-			receipt, _ := c.httpClient.Eth().GetTransactionReceipt(hash)
-			if receipt != nil {
+		case <-heads:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s not confirmed after %d blocks: %w", hash, num, ctx.Err())
+		}
+	}
+}
 
-				if receipt.Status != 1 {
-					panic("not success")
-				}
+// subscribeHeads returns a channel that is notified on every new block using
+// an eth_subscribe("newHeads") subscription. It is only available for
+// transports that support subscriptions (websocket and ipc).
+func (c *client) subscribeHeads() (<-chan struct{}, func(), error) {
+	if !c.httpClient.SubscriptionEnabled() {
+		return nil, nil, fmt.Errorf("transport does not support subscriptions")
+	}
+
+	heads := make(chan struct{}, 1)
+	cancel, err := c.httpClient.Subscribe("newHeads", func(b []byte) {
+		// notifications are coalesced if the consumer is busy
+		select {
+		case heads <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	stop := func() {
+		cancel()
+	}
+	return heads, stop, nil
+}
+
+// watchHeads returns a channel that is notified whenever there might be a
+// new block. It uses a subscription if the transport supports it or it
+// falls back to polling at the given interval otherwise.
+func (c *client) watchHeads(interval time.Duration) (<-chan struct{}, func()) {
+	if heads, stop, err := c.subscribeHeads(); err == nil {
+		return heads, stop
+	}
 
-				return hash, receipt, nil
+	ticker := time.NewTicker(interval)
+	heads := make(chan struct{})
+	closeCh := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				select {
+				case heads <- struct{}{}:
+				case <-closeCh:
+					return
+				}
+			case <-closeCh:
+				return
 			}
-		case <-tt.C:
-			panic("not found")
 		}
+	}()
+	stop := func() {
+		ticker.Stop()
+		close(closeCh)
+	}
+	return heads, stop
+}
+
+// canonicalReceipt returns the receipt of a transaction only if the block
+// that includes it is part of the canonical chain. It returns nil if the
+// transaction is not found or it was reorged out.
+func (c *client) canonicalReceipt(hash ethgo.Hash) (*ethgo.Receipt, error) {
+	receipt, err := c.httpClient.Eth().GetTransactionReceipt(hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, nil
 	}
+
+	block, err := c.httpClient.Eth().GetBlockByNumber(ethgo.BlockNumber(receipt.BlockNumber), false)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || block.Hash != receipt.BlockHash {
+		return nil, nil
+	}
+	return receipt, nil
+}
+
+// includedReceipt checks that a transaction is still included in the block
+// 'num' with the hash 'blockHash'. It returns false only if the canonical block
+// at that height has another hash and the transaction is not included in any
+// other block. A missing receipt is not a reorg since the nodes prune the index
+// of old transactions and a failover endpoint may lag behind. The receipt is
+// only returned if the transaction was included again in another block.
+func (c *client) includedReceipt(hash ethgo.Hash, num uint64, blockHash ethgo.Hash) (*ethgo.Receipt, bool, error) {
+	block, err := c.httpClient.Eth().GetBlockByNumber(ethgo.BlockNumber(num), false)
+	if err != nil {
+		return nil, false, err
+	}
+	if block == nil || block.Hash == blockHash {
+		// the block is still canonical or the node is behind
+		return nil, true, nil
+	}
+
+	receipt, err := c.canonicalReceipt(hash)
+	if err != nil {
+		return nil, false, err
+	}
+	return receipt, receipt != nil, nil
+}
+
+// waitForReceipt polls the node until the transaction is included in a block
+// or the context is done. The context carries the timeout of the resource.
+func (c *client) waitForReceipt(ctx context.Context, hash ethgo.Hash) (*ethgo.Receipt, error) {
+	heads, stop := c.watchHeads(receiptPollInterval)
+	defer stop()
+
+	for {
+		receipt, _ := c.httpClient.Eth().GetTransactionReceipt(hash)
+		if receipt != nil {
+			return receipt, nil
+		}
+
+		select {
+		case <-heads:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s not included in a block: %w", hash, ctx.Err())
+		}
+	}
+}
+
+// replayRevertReason executes again a reverted transaction with eth_call
+// on the block where it was included to recover the revert reason.
+func (c *client) replayRevertReason(msg *ethgo.CallMsg, block uint64, contractAbi *abi.ABI) string {
+	_, err := c.httpClient.Eth().Call(msg, ethgo.BlockNumber(block))
+	if err == nil {
+		return ""
+	}
+	data, ok := revertData(err)
+	if !ok {
+		return ""
+	}
+	return decodeRevertReason(data, contractAbi)
+}
+
+// revertData extracts the revert data from the error returned by the node
+// when a call reverts. Nodes return it either as a hex string in the data
+// field of the error or nested in an object (i.e. hardhat).
+func revertData(err error) ([]byte, bool) {
+	var obj *codec.ErrorObject
+	if !errors.As(err, &obj) {
+		return nil, false
+	}
+
+	data := obj.Data
+	if nested, ok := data.(map[string]interface{}); ok {
+		data = nested["data"]
+	}
+	str, ok := data.(string)
+	if !ok || !strings.HasPrefix(str, "0x") {
+		return nil, false
+	}
+	buf, err := hex.DecodeString(str[2:])
+	if err != nil {
+		return nil, false
+	}
+	return buf, true
+}
+
+// fillDynamicFees sets the fees of a dynamic fee transaction that are
+// not already set. The priority fee defaults to the one suggested by the node
+// and the max fee leaves enough room for the base fee to double.
+func (c *client) fillDynamicFees(txn *transaction) error {
+	if txn.MaxPriorityFeePerGas == nil {
+		var tip jsonrpc.ArgBig
+		if err := c.httpClient.Call("eth_maxPriorityFeePerGas", &tip); err != nil {
+			return fmt.Errorf("failed to get max priority fee: %v", err)
+		}
+		txn.MaxPriorityFeePerGas = tip.Big()
+
+		// the suggested tip cannot be higher than a user provided max fee
+		if txn.MaxFeePerGas != nil && txn.MaxPriorityFeePerGas.Cmp(txn.MaxFeePerGas) > 0 {
+			txn.MaxPriorityFeePerGas = new(big.Int).Set(txn.MaxFeePerGas)
+		}
+	}
+
+	if txn.MaxFeePerGas == nil {
+		baseFee, err := c.baseFee()
+		if err != nil {
+			return err
+		}
+		txn.MaxFeePerGas = dynamicFeeCap(baseFee, txn.MaxPriorityFeePerGas)
+	}
+
+	if txn.MaxPriorityFeePerGas.Cmp(txn.MaxFeePerGas) > 0 {
+		return fmt.Errorf("max priority fee per gas (%s) is higher than max fee per gas (%s)", txn.MaxPriorityFeePerGas, txn.MaxFeePerGas)
+	}
+	return nil
+}
+
+// baseFee returns the base fee of the latest block
+func (c *client) baseFee() (*big.Int, error) {
+	var header struct {
+		BaseFeePerGas *jsonrpc.ArgBig `json:"baseFeePerGas"`
+	}
+	if err := c.httpClient.Call("eth_getBlockByNumber", &header, ethgo.Latest.String(), false); err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %v", err)
+	}
+	if header.BaseFeePerGas == nil {
+		return nil, fmt.Errorf("the chain does not support dynamic fee transactions, use the legacy type instead")
+	}
+	return header.BaseFeePerGas.Big(), nil
+}
+
+// dynamicFeeCap returns the max fee per gas for a given base fee and tip.
+func dynamicFeeCap(baseFee, tip *big.Int) *big.Int {
+	feeCap := new(big.Int).Mul(baseFee, big.NewInt(2))
+	return feeCap.Add(feeCap, tip)
 }
 
 func (c *client) filterTransactions(ctx context.Context, input filterTransactionInput) (ethgo.Hash, error) {
@@ -129,6 +927,10 @@ func (c *client) filterTransactions(ctx context.Context, input filterTransaction
 		input: input,
 		clt:   c.httpClient.Eth(),
 	}
+	if heads, stop, err := c.subscribeHeads(); err == nil {
+		defer stop()
+		mngr.heads = heads
+	}
 	return mngr.run(ctx)
 }
 
@@ -149,6 +951,10 @@ type transactionFilter struct {
 	input      filterTransactionInput
 	clt        transactionFilterClient
 	waitPeriod time.Duration
+
+	// heads notifies about new blocks. If not set, the
+	// filter polls the chain every 'waitPeriod'.
+	heads <-chan struct{}
 }
 
 func (t *transactionFilter) run(ctx context.Context) (ethgo.Hash, error) {
@@ -201,17 +1007,33 @@ func (t *transactionFilter) run(ctx context.Context) (ethgo.Hash, error) {
 				break
 			}
 
-			// sleep for n seconds and try again or exit if the context
-			// was canceled
-			select {
-			case <-time.After(t.waitPeriod):
-			case <-ctx.Done():
-				return ethgo.Hash{}, ctx.Err()
+			// wait for a new head (or sleep for n seconds) and try
+			// again or exit if the context was canceled
+			if err := t.waitForHead(ctx); err != nil {
+				return ethgo.Hash{}, err
 			}
 		}
 	}
 }
 
+func (t *transactionFilter) waitForHead(ctx context.Context) error {
+	if t.heads != nil {
+		select {
+		case <-t.heads:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case <-time.After(t.waitPeriod):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func validateTxn(txn *ethgo.Transaction, input filterTransactionInput) bool {
 	if input.From != nil && txn.From != *input.From {
 		return false
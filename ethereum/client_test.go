@@ -2,22 +2,31 @@ package ethereum
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
 	"github.com/umbracle/ethgo/wallet"
 )
 
-var defTestSigner = []byte{}
+var defTestSigner transactionSigner
 
 func init() {
 	key, _ := wallet.NewWalletFromMnemonic("test test test test test test test test test test test junk")
-	defTestSigner, _ = key.MarshallPrivateKey()
+	defTestSigner = &keySigner{key: key}
 }
 
 func TestClient_SendTransaction_Simple(t *testing.T) {
@@ -37,7 +46,7 @@ func TestClient_SendTransaction_Simple(t *testing.T) {
 		Signer: defTestSigner,
 	}
 
-	_, receipt, err := clt.sendTransaction(txn)
+	_, receipt, err := clt.sendTransaction(context.Background(), txn)
 	require.NoError(t, err)
 	require.Equal(t, receipt.Status, uint64(1))
 	require.NoError(t, err)
@@ -71,7 +80,7 @@ func TestClient_SendTransaction_Concurrent(t *testing.T) {
 				Signer: defTestSigner,
 			}
 
-			_, receipt, err := clt.sendTransaction(txn)
+			_, receipt, err := clt.sendTransaction(context.Background(), txn)
 			require.NoError(t, err)
 			require.Equal(t, receipt.Status, uint64(1))
 			require.NoError(t, err)
@@ -311,3 +320,687 @@ func TestTransactionFilter_ValidateInput(t *testing.T) {
 		require.Equal(t, c.valid, validateTxn(c.txn, c.input))
 	}
 }
+
+func TestClient_SendTransaction_DynamicFee(t *testing.T) {
+	testAccPreCheck(t)
+
+	clt, _ := newClient("")
+
+	acct, _ := wallet.GenerateKey()
+	target := acct.Address()
+
+	txn := &transaction{
+		To:     &target,
+		Value:  big.NewInt(100000),
+		Signer: defTestSigner,
+		Type:   ethgo.TransactionDynamicFee,
+	}
+
+	hash, receipt, err := clt.sendTransaction(context.Background(), txn)
+	require.NoError(t, err)
+	require.Equal(t, receipt.Status, uint64(1))
+
+	// the fees suggested by the node are filled in
+	require.NotNil(t, txn.MaxFeePerGas)
+	require.NotNil(t, txn.MaxPriorityFeePerGas)
+
+	sent, err := clt.Http().GetTransactionByHash(hash)
+	require.NoError(t, err)
+	require.Equal(t, ethgo.TransactionDynamicFee, sent.Type)
+}
+
+func TestClient_SendTransaction_AccessList(t *testing.T) {
+	testAccPreCheck(t)
+
+	clt, _ := newClient("")
+
+	acct, _ := wallet.GenerateKey()
+	target := acct.Address()
+
+	txn := &transaction{
+		To:     &target,
+		Value:  big.NewInt(100000),
+		Signer: defTestSigner,
+		Type:   ethgo.TransactionAccessList,
+		AccessList: ethgo.AccessList{
+			{Address: target, Storage: []ethgo.Hash{{0x1}}},
+		},
+	}
+
+	hash, receipt, err := clt.sendTransaction(context.Background(), txn)
+	require.NoError(t, err)
+	require.Equal(t, receipt.Status, uint64(1))
+
+	sent, err := clt.Http().GetTransactionByHash(hash)
+	require.NoError(t, err)
+	require.Equal(t, ethgo.TransactionAccessList, sent.Type)
+	require.Len(t, sent.AccessList, 1)
+}
+
+func TestClient_DynamicFeeCap(t *testing.T) {
+	require.Equal(t, big.NewInt(25), dynamicFeeCap(big.NewInt(10), big.NewInt(5)))
+	require.Equal(t, big.NewInt(1), dynamicFeeCap(big.NewInt(0), big.NewInt(1)))
+}
+
+func TestClient_RevertData(t *testing.T) {
+	// hex data in the error object
+	data, ok := revertData(&codec.ErrorObject{Code: 3, Message: "execution reverted", Data: "0x4e487b71"})
+	require.True(t, ok)
+	require.Equal(t, []byte{0x4e, 0x48, 0x7b, 0x71}, data)
+
+	// hex data nested in an object
+	data, ok = revertData(&codec.ErrorObject{Data: map[string]interface{}{"data": "0x01"}})
+	require.True(t, ok)
+	require.Equal(t, []byte{0x01}, data)
+
+	_, ok = revertData(fmt.Errorf("not a jsonrpc error"))
+	require.False(t, ok)
+}
+
+func TestClient_WaitForReceipt_Timeout(t *testing.T) {
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			return nil, nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_, err = clt.waitForReceipt(ctx, ethgo.Hash{0x1})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type testRPCHandler func(params []json.RawMessage) (interface{}, error)
+
+// newTestRPCServer starts an http server that serves the jsonrpc
+// methods in the handlers map.
+func newTestRPCServer(t *testing.T, handlers map[string]testRPCHandler) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{}       `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+		}
+		handler, ok := handlers[req.Method]
+		if !ok {
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		} else if result, err := handler(req.Params); err != nil {
+			obj, ok := err.(*codec.ErrorObject)
+			if !ok {
+				obj = &codec.ErrorObject{Code: -32000, Message: err.Error()}
+			}
+			resp["error"] = obj
+		} else {
+			resp["result"] = result
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestClient_CanonicalReceipt(t *testing.T) {
+	receiptBlockHash := ethgo.Hash{0x1}
+	canonicalHash := ethgo.Hash{0x1}
+
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			return testReceipt(10, receiptBlockHash), nil
+		},
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			return testBlock(10, canonicalHash), nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	receipt, err := clt.canonicalReceipt(ethgo.Hash{0x2})
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Equal(t, uint64(10), receipt.BlockNumber)
+
+	// the block at height 10 changed, the transaction was reorged out
+	canonicalHash = ethgo.Hash{0x3}
+
+	receipt, err = clt.canonicalReceipt(ethgo.Hash{0x2})
+	require.NoError(t, err)
+	require.Nil(t, receipt)
+}
+
+func TestClient_IncludedReceipt(t *testing.T) {
+	var receipt map[string]interface{}
+	blocks := map[string]ethgo.Hash{
+		"0xa": {0x1},
+	}
+
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			return receipt, nil
+		},
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			var num string
+			if err := json.Unmarshal(params[0], &num); err != nil {
+				return nil, err
+			}
+			hash, ok := blocks[num]
+			if !ok {
+				return nil, nil
+			}
+			n, _ := strconv.ParseUint(strings.TrimPrefix(num, "0x"), 16, 64)
+			return testBlock(n, hash), nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	// the receipt was pruned by the node but the block is canonical
+	found, included, err := clt.includedReceipt(ethgo.Hash{0x2}, 10, ethgo.Hash{0x1})
+	require.NoError(t, err)
+	require.True(t, included)
+	require.Nil(t, found)
+
+	// the node is behind the block of the transaction
+	_, included, err = clt.includedReceipt(ethgo.Hash{0x2}, 11, ethgo.Hash{0x4})
+	require.NoError(t, err)
+	require.True(t, included)
+
+	// the block was reorged out and the transaction is not included
+	blocks["0xa"] = ethgo.Hash{0x3}
+	_, included, err = clt.includedReceipt(ethgo.Hash{0x2}, 10, ethgo.Hash{0x1})
+	require.NoError(t, err)
+	require.False(t, included)
+
+	// the transaction was included again in another block
+	blocks["0xb"] = ethgo.Hash{0x5}
+	receipt = testReceipt(11, ethgo.Hash{0x5})
+	found, included, err = clt.includedReceipt(ethgo.Hash{0x2}, 10, ethgo.Hash{0x1})
+	require.NoError(t, err)
+	require.True(t, included)
+	require.Equal(t, uint64(11), found.BlockNumber)
+}
+
+func TestClient_WaitForConfirmations_StaleReceipt(t *testing.T) {
+	var lock sync.Mutex
+	calls := 0
+
+	// the node returns the receipt of a block that is not canonical anymore
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+			return "0x20", nil
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			calls++
+			return testReceipt(10, ethgo.Hash{0x1}), nil
+		},
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			return testBlock(10, ethgo.Hash{0x3}), nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*receiptPollInterval)
+	defer cancel()
+
+	receipt := &ethgo.Receipt{BlockNumber: 10, BlockHash: ethgo.Hash{0x1}}
+	_, err = clt.waitForConfirmations(ctx, ethgo.Hash{0x2}, receipt, 2)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the receipt is only queried again on new heads
+	lock.Lock()
+	defer lock.Unlock()
+	require.LessOrEqual(t, calls, 20)
+}
+
+// testReceipt returns the jsonrpc encoding of a successful receipt
+func testReceipt(num uint64, blockHash ethgo.Hash) map[string]interface{} {
+	return map[string]interface{}{
+		"transactionHash":   ethgo.Hash{0x2}.String(),
+		"transactionIndex":  "0x0",
+		"blockHash":         blockHash.String(),
+		"blockNumber":       fmt.Sprintf("0x%x", num),
+		"from":              ethgo.Address{0x1}.String(),
+		"contractAddress":   nil,
+		"gasUsed":           "0x5208",
+		"cumulativeGasUsed": "0x5208",
+		"logsBloom":         "0x" + strings.Repeat("00", 256),
+		"logs":              []interface{}{},
+		"status":            "0x1",
+	}
+}
+
+// testBlock returns the jsonrpc encoding of a block header
+func testBlock(num uint64, hash ethgo.Hash) map[string]interface{} {
+	return map[string]interface{}{
+		"number":           fmt.Sprintf("0x%x", num),
+		"hash":             hash.String(),
+		"parentHash":       ethgo.Hash{}.String(),
+		"sha3Uncles":       ethgo.Hash{}.String(),
+		"transactionsRoot": ethgo.Hash{}.String(),
+		"stateRoot":        ethgo.Hash{}.String(),
+		"receiptsRoot":     ethgo.Hash{}.String(),
+		"miner":            ethgo.Address{}.String(),
+		"gasLimit":         "0x1c9c380",
+		"gasUsed":          "0x0",
+		"timestamp":        "0x0",
+		"difficulty":       "0x0",
+		"extraData":        "0x",
+		"baseFeePerGas":    "0x7",
+		"transactions":     []interface{}{},
+		"uncles":           []interface{}{},
+	}
+}
+
+func TestClient_WaitForReceipt_Subscription(t *testing.T) {
+	var lock sync.Mutex
+	included := false
+	calls := 0
+
+	path, notifyHead := newTestIPCServer(t, map[string]testRPCHandler{
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			calls++
+			if !included {
+				return nil, nil
+			}
+			return testReceipt(1, ethgo.Hash{0x1}), nil
+		},
+	})
+
+	clt, err := newClient(path)
+	require.NoError(t, err)
+	defer clt.Close()
+
+	doneCh := make(chan *ethgo.Receipt)
+	go func() {
+		receipt, err := clt.waitForReceipt(context.Background(), ethgo.Hash{0x2})
+		require.NoError(t, err)
+		doneCh <- receipt
+	}()
+
+	// the receipt is not polled while there are no new heads
+	time.Sleep(500 * time.Millisecond)
+
+	lock.Lock()
+	require.Equal(t, 1, calls)
+	included = true
+	lock.Unlock()
+
+	notifyHead()
+
+	select {
+	case receipt := <-doneCh:
+		require.Equal(t, uint64(1), receipt.BlockNumber)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout")
+	}
+}
+
+// newTestIPCServer starts a jsonrpc server over an unix socket that supports
+// the newHeads subscription. It returns the path of the socket and a function
+// to notify a new head to the subscribers.
+func newTestIPCServer(t *testing.T, handlers map[string]testRPCHandler) (string, func()) {
+	path := filepath.Join(t.TempDir(), "rpc.ipc")
+
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	var lock sync.Mutex
+	var subscribers []*json.Encoder
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+
+				dec := json.NewDecoder(conn)
+				enc := json.NewEncoder(conn)
+				for {
+					var req struct {
+						ID     uint64            `json:"id"`
+						Method string            `json:"method"`
+						Params []json.RawMessage `json:"params"`
+					}
+					if err := dec.Decode(&req); err != nil {
+						return
+					}
+
+					resp := map[string]interface{}{
+						"jsonrpc": "2.0",
+						"id":      req.ID,
+					}
+					if req.Method == "eth_subscribe" {
+						resp["result"] = "0x1"
+					} else if handler, ok := handlers[req.Method]; !ok {
+						resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+					} else if result, err := handler(req.Params); err != nil {
+						resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+					} else {
+						resp["result"] = result
+					}
+
+					lock.Lock()
+					enc.Encode(resp)
+					if req.Method == "eth_subscribe" {
+						subscribers = append(subscribers, enc)
+					}
+					lock.Unlock()
+				}
+			}(conn)
+		}
+	}()
+
+	notifyHead := func() {
+		lock.Lock()
+		defer lock.Unlock()
+
+		for _, enc := range subscribers {
+			enc.Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "eth_subscription",
+				"params": map[string]interface{}{
+					"subscription": "0x1",
+					"result":       testBlock(1, ethgo.Hash{0x1}),
+				},
+			})
+		}
+	}
+	return path, notifyHead
+}
+
+func TestTransactionFilter_Heads(t *testing.T) {
+	mock := &mockTransactionFilterClient{}
+	mock.move(10)
+
+	heads := make(chan struct{}, 1)
+	mngr := &transactionFilter{
+		input: filterTransactionInput{
+			StartBlock:  1,
+			LimitBlocks: uintPtr(15),
+		},
+		clt: mock,
+		// the filter must not wait for the polling period
+		waitPeriod: time.Hour,
+		heads:      heads,
+	}
+
+	doneCh := make(chan struct{})
+	go func() {
+		mngr.run(context.Background())
+		close(doneCh)
+	}()
+
+	mock.move(10)
+	heads <- struct{}{}
+
+	select {
+	case <-doneCh:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout")
+	}
+	require.Equal(t, uint64(16), mock.latestQueried)
+}
+
+func TestClient_ChainIDCached(t *testing.T) {
+	calls := 0
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_chainId": func(params []json.RawMessage) (interface{}, error) {
+			calls++
+			return "0x5", nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		chainID, err := clt.getChainID()
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(5), chainID)
+	}
+	require.Equal(t, 1, calls)
+}
+
+func TestClient_SimulateTransaction(t *testing.T) {
+	reverts := false
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_call": func(params []json.RawMessage) (interface{}, error) {
+			if reverts {
+				// Error("not allowed")
+				reason := hex.EncodeToString([]byte("not allowed"))
+				data := "0x08c379a0" +
+					fmt.Sprintf("%064x", 0x20) +
+					fmt.Sprintf("%064x", len("not allowed")) +
+					reason + strings.Repeat("0", 64-len(reason))
+				return nil, &codec.ErrorObject{Code: 3, Message: "execution reverted", Data: data}
+			}
+			return "0x", nil
+		},
+		"eth_estimateGas": func(params []json.RawMessage) (interface{}, error) {
+			return "0x5208", nil
+		},
+		"eth_maxPriorityFeePerGas": func(params []json.RawMessage) (interface{}, error) {
+			return "0x2", nil
+		},
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			return testBlock(1, ethgo.Hash{0x1}), nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	txn := &transaction{
+		To:     &ethgo.Address{0x1},
+		Signer: &keySigner{key: key},
+		Type:   ethgo.TransactionDynamicFee,
+	}
+
+	// the fee is (base fee + tip) * gas
+	gas, fee, err := clt.simulateTransaction(txn)
+	require.NoError(t, err)
+	require.Equal(t, uint64(21000), gas)
+	require.Equal(t, big.NewInt(9*21000), fee)
+
+	reverts = true
+
+	_, _, err = clt.simulateTransaction(txn)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "execution reverted: not allowed")
+}
+
+func TestClient_FillAccessList(t *testing.T) {
+	gasWithList := "0x5000"
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_createAccessList": func(params []json.RawMessage) (interface{}, error) {
+			return map[string]interface{}{
+				"accessList": []interface{}{
+					map[string]interface{}{
+						"address":     ethgo.Address{0x2}.String(),
+						"storageKeys": []string{ethgo.Hash{0x3}.String()},
+					},
+				},
+				"gasUsed": "0x5000",
+			}, nil
+		},
+		"eth_estimateGas": func(params []json.RawMessage) (interface{}, error) {
+			var args map[string]interface{}
+			if err := json.Unmarshal(params[0], &args); err != nil {
+				return nil, err
+			}
+			if _, ok := args["accessList"]; ok {
+				return gasWithList, nil
+			}
+			return "0x5208", nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	// the access list lowers the gas
+	txn := &transaction{To: &ethgo.Address{0x1}}
+	gas, err := clt.fillAccessList(ethgo.Address{0x1}, txn)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x5000), gas)
+	require.Equal(t, ethgo.AccessList{{Address: ethgo.Address{0x2}, Storage: []ethgo.Hash{{0x3}}}}, txn.AccessList)
+
+	// the access list does not lower the gas
+	gasWithList = "0x6000"
+
+	txn = &transaction{To: &ethgo.Address{0x1}}
+	gas, err = clt.fillAccessList(ethgo.Address{0x1}, txn)
+	require.NoError(t, err)
+	require.Zero(t, gas)
+	require.Nil(t, txn.AccessList)
+}
+
+func TestClient_SendUnsignedTransaction(t *testing.T) {
+	from := ethgo.Address{0x1}
+
+	var impersonated []string
+	var sent map[string]interface{}
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"anvil_impersonateAccount": func(params []json.RawMessage) (interface{}, error) {
+			var addr string
+			if err := json.Unmarshal(params[0], &addr); err != nil {
+				return nil, err
+			}
+			impersonated = append(impersonated, addr)
+			return nil, nil
+		},
+		"eth_sendTransaction": func(params []json.RawMessage) (interface{}, error) {
+			if err := json.Unmarshal(params[0], &sent); err != nil {
+				return nil, err
+			}
+			return ethgo.Hash{0x2}.String(), nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	ethTxn := &ethgo.Transaction{
+		To:       &ethgo.Address{0x3},
+		Value:    big.NewInt(1),
+		Gas:      21000,
+		GasPrice: 10,
+		Nonce:    5,
+	}
+
+	// the transaction is sent unsigned from the account of the node
+	hash, err := clt.signAndSend(context.Background(), &nodeSigner{address: from}, big.NewInt(1), ethTxn)
+	require.NoError(t, err)
+	require.Equal(t, ethgo.Hash{0x2}, hash)
+	require.Empty(t, impersonated)
+	require.Equal(t, from.String(), sent["from"])
+	require.Equal(t, "0x5", sent["nonce"])
+
+	// the account is impersonated before sending the transaction
+	_, err = clt.signAndSend(context.Background(), &nodeSigner{address: from, impersonate: true}, big.NewInt(1), ethTxn)
+	require.NoError(t, err)
+	require.Equal(t, []string{from.String()}, impersonated)
+}
+
+func TestClient_SignAndSend_AlreadyKnown(t *testing.T) {
+	// the first endpoint broadcasts the transaction but the request fails
+	var broadcast []byte
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []string `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err == nil && len(req.Params) == 1 {
+			broadcast, _ = hex.DecodeString(strings.TrimPrefix(req.Params[0], "0x"))
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	// the request fails over to an endpoint that already has the transaction
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+			return nil, fmt.Errorf("already known")
+		},
+	})
+
+	clt, err := newClientWithConfig(&clientConfig{
+		Hosts: []string{failing.URL, srv.URL},
+	})
+	require.NoError(t, err)
+	defer clt.Close()
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	ethTxn := &ethgo.Transaction{
+		To:       &ethgo.Address{0x3},
+		Value:    big.NewInt(1),
+		Gas:      21000,
+		GasPrice: 10,
+	}
+	hash, err := clt.signAndSend(context.Background(), &keySigner{key: key}, big.NewInt(1), ethTxn)
+	require.NoError(t, err)
+	require.NotEmpty(t, broadcast)
+	require.Equal(t, ethgo.BytesToHash(ethgo.Keccak256(broadcast)), hash)
+}
+
+func TestClient_SendRawTransaction(t *testing.T) {
+	raw := []byte{0x1, 0x2, 0x3}
+	hash := ethgo.BytesToHash(ethgo.Keccak256(raw))
+
+	sendErr := "already known"
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+			return nil, fmt.Errorf("%s", sendErr)
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			return testReceipt(1, ethgo.Hash{0x1}), nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	// the transaction is already in the pool
+	found, receipt, err := clt.sendRawTransaction(context.Background(), raw)
+	require.NoError(t, err)
+	require.Equal(t, hash, found)
+	require.Equal(t, uint64(1), receipt.BlockNumber)
+
+	// the node rejects transactions without chain id
+	sendErr = "only replay-protected (EIP-155) transactions allowed over RPC"
+
+	_, _, err = clt.sendRawTransaction(context.Background(), raw)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "allow-unprotected-txs")
+}
@@ -0,0 +1,71 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os/exec"
+	"strings"
+
+	"github.com/umbracle/ethgo"
+)
+
+// commandSigner signs the transactions with an external executable. The
+// unsigned transaction is written as json to the stdin of the command (with the
+// same format as eth_signTransaction) and the signed raw transaction is read
+// back from the stdout.
+type commandSigner struct {
+	command []string
+	address ethgo.Address
+}
+
+func newCommandSigner(command []string, address ethgo.Address) *commandSigner {
+	return &commandSigner{
+		command: command,
+		address: address,
+	}
+}
+
+func (c *commandSigner) Address() ethgo.Address {
+	return c.address
+}
+
+func (c *commandSigner) SignTransaction(ctx context.Context, txn *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	input, err := json.Marshal(signTransactionArgs(c.address, txn, chainID))
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	// the command is killed when the context is done
+	cmd := exec.CommandContext(ctx, c.command[0], c.command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("signer command failed: %v", err)
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cmd.Wait()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("signer command failed: %v: %s", err, msg)
+			}
+			return nil, fmt.Errorf("signer command failed: %v", err)
+		}
+	case <-ctx.Done():
+		// the children of the command may keep its output open after it
+		// is killed, so it is not awaited
+		return nil, fmt.Errorf("signer command failed: %w", ctx.Err())
+	}
+	return decodeSignedTransaction(stdout.Bytes(), txn, chainID, c.address)
+}
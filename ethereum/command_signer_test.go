@@ -0,0 +1,84 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+func TestCommandSigner(t *testing.T) {
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	local := &keySigner{key: key}
+	chainID := big.NewInt(5)
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.json")
+	output := filepath.Join(dir, "output")
+
+	// the command stores the input and returns the transaction signed by the test
+	signer := newCommandSigner([]string{"sh", "-c", "cat > " + input + " && cat " + output}, key.Address())
+	require.Equal(t, key.Address(), signer.Address())
+
+	for _, txn := range testRemoteSignerTxns() {
+		clone := *txn
+		expected, err := local.SignTransaction(context.Background(), &clone, chainID)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(output, []byte("0x"+hex.EncodeToString(expected)+"\n"), 0600))
+
+		raw, err := signer.SignTransaction(context.Background(), txn, chainID)
+		require.NoError(t, err)
+		require.Equal(t, expected, raw)
+
+		data, err := os.ReadFile(input)
+		require.NoError(t, err)
+
+		var args map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &args))
+		require.Equal(t, key.Address().String(), args["from"])
+		require.Equal(t, "0x5", args["chainId"])
+	}
+
+	// the transaction signed by a different account is rejected
+	other, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	txn := testRemoteSignerTxns()[0]
+	clone := *txn
+	raw, err := (&keySigner{key: other}).SignTransaction(context.Background(), &clone, chainID)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(output, []byte(hex.EncodeToString(raw)), 0600))
+
+	_, err = signer.SignTransaction(context.Background(), txn, chainID)
+	require.Error(t, err)
+
+	// the errors of the command are returned
+	failing := newCommandSigner([]string{"sh", "-c", "echo 'signing rejected' >&2; exit 1"}, key.Address())
+
+	_, err = failing.SignTransaction(context.Background(), txn, chainID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "signing rejected")
+}
+
+func TestCommandSigner_Timeout(t *testing.T) {
+	// the command hangs (i.e. waiting for a hardware wallet)
+	signer := newCommandSigner([]string{"sh", "-c", "sleep 10"}, ethgo.Address{0x1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := signer.SignTransaction(ctx, testRemoteSignerTxns()[0], big.NewInt(5))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,95 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceAccounts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceAccountsRead,
+		Description: "Derive a list of accounts from a mnemonic.",
+		Schema: map[string]*schema.Schema{
+			"mnemonic": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The mnemonic of the wallet.",
+			},
+			"passphrase": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The passphrase (BIP-39) of the mnemonic.",
+			},
+			"derivation_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The derivation path of the parent key of the accounts. The accounts are derived at derivation_path/index. Defaults to m/44'/60'/0'/0.",
+			},
+			"start_index": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The index of the first account. Defaults to 0.",
+			},
+			"num_accounts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of accounts to derive. Defaults to 10.",
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The addresses of the accounts.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"signers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The signers of the accounts. These are the private keys of the accounts.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func datasourceAccountsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	path, err := decodeDerivationPath(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	mnemonic := d.Get("mnemonic").(string)
+	passphrase := d.Get("passphrase").(string)
+	start := d.Get("start_index").(int)
+
+	addresses := []string{}
+	signers := []string{}
+	for i := start; i < start+d.Get("num_accounts").(int); i++ {
+		key, err := deriveKey(mnemonic, passphrase, accountDerivationPath(path, uint32(i)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		priv, err := key.MarshallPrivateKey()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		addresses = append(addresses, key.Address().String())
+		signers = append(signers, hex.EncodeToString(priv))
+	}
+
+	d.SetId(addresses[0])
+	d.Set("addresses", addresses)
+	d.Set("signers", signers)
+	return nil
+}
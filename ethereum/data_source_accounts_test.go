@@ -0,0 +1,35 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccounts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_accounts" "accounts" {
+					mnemonic     = "test test test test test test test test test test test junk"
+					start_index  = 1
+					num_accounts = 2
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ethereum_accounts.accounts", "addresses.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.ethereum_accounts.accounts", "addresses.0", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+					resource.TestCheckResourceAttr(
+						"data.ethereum_accounts.accounts", "addresses.1", "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"),
+					resource.TestCheckResourceAttr(
+						"data.ethereum_accounts.accounts", "signers.0", "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"),
+				),
+			},
+		},
+	})
+}
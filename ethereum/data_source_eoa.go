@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/umbracle/ethgo/wallet"
 )
 
@@ -27,6 +28,26 @@ func datasourceEoa() *schema.Resource {
 				ConflictsWith: []string{"mnemonic"},
 				Description:   "The private key of the wallet to use.",
 			},
+			"derivation_path": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"privkey"},
+				Description:   "The derivation path of the parent key of the accounts of the mnemonic. The account is derived at derivation_path/index. Defaults to m/44'/60'/0'/0.",
+			},
+			"index": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"privkey"},
+				ValidateFunc:  validation.IntAtLeast(0),
+				Description:   "The index of the account derived from the mnemonic. Defaults to 0.",
+			},
+			"passphrase": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"privkey"},
+				Description:   "The passphrase (BIP-39) of the mnemonic.",
+			},
 			"address": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -48,7 +69,13 @@ func datasourceEoaRead(ctx context.Context, d *schema.ResourceData, m interface{
 	)
 
 	if mnemonic, ok := d.GetOk("mnemonic"); ok {
-		key, err = wallet.NewWalletFromMnemonic(mnemonic.(string))
+		path, err := decodeDerivationPath(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		index := uint32(d.Get("index").(int))
+
+		key, err = deriveKey(mnemonic.(string), d.Get("passphrase").(string), accountDerivationPath(path, index))
 		if err != nil {
 			return diag.FromErr(err)
 		}
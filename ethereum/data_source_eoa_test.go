@@ -49,3 +49,25 @@ func TestAccEOA_privKey(t *testing.T) {
 		},
 	})
 }
+
+func TestAccEOA_mnemonicIndex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_eoa" "account" {
+					mnemonic        = "test test test test test test test test test test test junk"
+					derivation_path = "m/44'/60'/0'/0"
+					index           = 1
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ethereum_eoa.account", "address", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+)
+
+// deterministicDeploymentProxy is the canonical CREATE2 factory (available at the
+// same address in most chains). It deploys the init code after the 32 bytes salt
+// of the calldata.
+const deterministicDeploymentProxy = "0x4e59b44847b379578588920cA78FbF26c0B4956C"
+
+// createXFactory is the canonical CreateX factory. It deploys with CREATE3 so that
+// the address of a contract only depends on the salt and not on the init code.
+const createXFactory = "0xba5Ed099633D3B313e4D5F7bdc1305d3c28ba5Ed"
+
+var (
+	// deployCreate3Selector is the selector of deployCreate3(bytes32,bytes) of CreateX
+	deployCreate3Selector = ethgo.Keccak256([]byte("deployCreate3(bytes32,bytes)"))[:4]
+
+	deployCreate3Type = abi.MustNewType("tuple(bytes32 salt, bytes initCode)")
+
+	// create3ProxyCodeHash is the hash of the init code of the proxy that CreateX
+	// deploys with CREATE2 and that deploys the contract with CREATE.
+	create3ProxyCodeHash = ethgo.Keccak256([]byte{0x67, 0x36, 0x3d, 0x3d, 0x37, 0x36, 0x3d, 0x34, 0xf0, 0x3d, 0x52, 0x60, 0x08, 0x60, 0x18, 0xf3})
+)
+
+// decodeSalt decodes a hex encoded salt of up to 32 bytes. Shorter
+// values are left padded with zeros.
+func decodeSalt(str string) ([32]byte, error) {
+	var salt [32]byte
+
+	buf, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return salt, fmt.Errorf("failed to decode salt '%s': %v", str, err)
+	}
+	if len(buf) > 32 {
+		return salt, fmt.Errorf("salt '%s' is longer than 32 bytes", str)
+	}
+	copy(salt[32-len(buf):], buf)
+	return salt, nil
+}
+
+func validateSalt(i interface{}, k string) ([]string, []error) {
+	if _, err := decodeSalt(i.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %v", k, err)}
+	}
+	return nil, nil
+}
+
+// create2Address returns the address of a contract deployed with CREATE2
+// by the factory (keccak256(0xff ++ factory ++ salt ++ keccak256(code))[12:]).
+func create2Address(factory ethgo.Address, salt [32]byte, initCodeHash []byte) ethgo.Address {
+	hash := ethgo.Keccak256([]byte{0xff}, factory[:], salt[:], initCodeHash)
+
+	var addr ethgo.Address
+	copy(addr[:], hash[12:])
+	return addr
+}
+
+// create3Deployment returns the calldata of the CreateX deployment and the address
+// of the contract. The salt is guarded by the sender: its first 20 bytes are the
+// address of the sender and the 21st byte is zero (no cross-chain redeploy
+// protection), so that only the sender can deploy to the same address in
+// every chain.
+func create3Deployment(factory, sender ethgo.Address, salt [32]byte, code []byte) ([]byte, ethgo.Address, error) {
+	copy(salt[:20], sender[:])
+	salt[20] = 0x0
+
+	input, err := deployCreate3Type.Encode([]interface{}{salt, code})
+	if err != nil {
+		return nil, ethgo.Address{}, err
+	}
+	input = append(append([]byte{}, deployCreate3Selector...), input...)
+
+	// salt of CreateX after the guard (keccak256(bytes32(sender) ++ salt))
+	var guarded [32]byte
+	copy(guarded[:], ethgo.Keccak256(make([]byte, 12), sender[:], salt[:]))
+
+	return input, create3Address(factory, guarded), nil
+}
+
+// create3Address returns the address of a contract deployed with CREATE3 by the
+// factory. The proxy deployed with CREATE2 creates the contract with nonce 1.
+func create3Address(factory ethgo.Address, salt [32]byte) ethgo.Address {
+	proxy := create2Address(factory, salt, create3ProxyCodeHash)
+	hash := ethgo.Keccak256([]byte{0xd6, 0x94}, proxy[:], []byte{0x01})
+
+	var addr ethgo.Address
+	copy(addr[:], hash[12:])
+	return addr
+}
+
+// hasCode returns true if there is a contract deployed at the address.
+func (c *client) hasCode(addr ethgo.Address) (bool, error) {
+	code, err := c.httpClient.Eth().GetCode(addr, ethgo.Latest)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code of %s: %v", addr, err)
+	}
+	return code != "" && code != "0x", nil
+}
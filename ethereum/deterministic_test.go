@@ -0,0 +1,94 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestDecodeSalt(t *testing.T) {
+	salt, err := decodeSalt("0xcafebabe")
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{28: 0xca, 29: 0xfe, 30: 0xba, 31: 0xbe}, salt)
+
+	salt, err = decodeSalt("")
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{}, salt)
+
+	_, err = decodeSalt("0xzz")
+	require.Error(t, err)
+
+	_, err = decodeSalt("0x" + hex.EncodeToString(make([]byte, 33)))
+	require.Error(t, err)
+}
+
+func TestCreate2Address(t *testing.T) {
+	// examples of EIP-1014
+	cases := []struct {
+		factory  string
+		salt     string
+		code     string
+		expected string
+	}{
+		{
+			"0x0000000000000000000000000000000000000000",
+			"0x00",
+			"00",
+			"0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38",
+		},
+		{
+			"0xdeadbeef00000000000000000000000000000000",
+			"0x00",
+			"00",
+			"0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3",
+		},
+		{
+			"0x00000000000000000000000000000000deadbeef",
+			"0xcafebabe",
+			"deadbeef",
+			"0x60f3f640a8508fC6a86d45DF051962668E1e8AC7",
+		},
+	}
+
+	for _, c := range cases {
+		salt, err := decodeSalt(c.salt)
+		require.NoError(t, err)
+		code, err := hex.DecodeString(c.code)
+		require.NoError(t, err)
+
+		addr := create2Address(ethgo.HexToAddress(c.factory), salt, ethgo.Keccak256(code))
+		require.Equal(t, c.expected, addr.String())
+	}
+}
+
+func TestCreate3Deployment(t *testing.T) {
+	factory := ethgo.HexToAddress(createXFactory)
+	sender := ethgo.Address{0x1}
+	salt, err := decodeSalt("0xcafebabe")
+	require.NoError(t, err)
+
+	input, addr, err := create3Deployment(factory, sender, salt, []byte{0x1, 0x2})
+	require.NoError(t, err)
+
+	// deployCreate3(bytes32,bytes)
+	require.Equal(t, "9c36a286", hex.EncodeToString(input[:4]))
+	require.Equal(t, "21c35dbe1b344a2488cf3321d6ce542f8e9f305544ff09e4993a62319a497c1f", hex.EncodeToString(create3ProxyCodeHash))
+
+	// the salt is guarded by the sender
+	guarded := input[4:36]
+	require.Equal(t, sender[:], guarded[:20])
+	require.Equal(t, byte(0), guarded[20])
+	require.Equal(t, salt[21:], guarded[21:])
+
+	// the address does not depend on the init code
+	_, other, err := create3Deployment(factory, sender, salt, []byte{0x3})
+	require.NoError(t, err)
+	require.Equal(t, addr, other)
+
+	// but it depends on the sender
+	_, other, err = create3Deployment(factory, ethgo.Address{0x2}, salt, []byte{0x1, 0x2})
+	require.NoError(t, err)
+	require.NotEqual(t, addr, other)
+}
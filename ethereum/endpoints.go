@@ -0,0 +1,276 @@
+package ethereum
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitCode is the jsonrpc error code used by most of the
+// providers to signal that the request limit was exceeded.
+const rateLimitCode = -32005
+
+// endpointPool is a jsonrpc proxy that forwards the requests to a list of
+// endpoints ordered by priority. A request fails over to the next endpoint on
+// connection errors, 5xx responses and rate limits. A periodic health check
+// compares the head of each endpoint and skips the ones that lag behind.
+type endpointPool struct {
+	endpoints []*endpoint
+	client    *http.Client
+	headers   map[string]string
+
+	// maxBlockLag is the number of blocks an endpoint can be behind
+	// the highest head before it is considered unhealthy.
+	maxBlockLag uint64
+
+	// path is the random path of the listener in the loopback interface.
+	// The requests to any other path are rejected since the pool adds the
+	// credentials of the endpoints to them.
+	path string
+
+	listener net.Listener
+	server   *http.Server
+	closeCh  chan struct{}
+}
+
+type endpoint struct {
+	url string
+
+	lock    sync.Mutex
+	healthy bool
+	head    uint64
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.healthy
+}
+
+func (e *endpoint) setHealthy(healthy bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.healthy = healthy
+}
+
+func newEndpointPool(config *clientConfig) (*endpointPool, error) {
+	if len(config.Hosts) == 0 {
+		return nil, fmt.Errorf("no endpoints provided")
+	}
+	client, err := config.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &endpointPool{
+		client:      client,
+		headers:     config.headers(),
+		maxBlockLag: config.MaxBlockLag,
+		closeCh:     make(chan struct{}),
+	}
+	for _, url := range config.Hosts {
+		p.endpoints = append(p.endpoints, &endpoint{url: url, healthy: true})
+	}
+
+	// the jsonrpc client of the provider connects to the pool through
+	// a listener in the loopback interface with a secret path.
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	p.path = "/" + hex.EncodeToString(secret)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p.listener = listener
+	p.server = &http.Server{Handler: p}
+
+	go p.server.Serve(listener)
+
+	if len(p.endpoints) > 1 {
+		p.healthCheck()
+		if config.HealthCheckInterval != 0 {
+			go p.runHealthCheck(config.HealthCheckInterval)
+		}
+	}
+	return p, nil
+}
+
+// URL returns the address of the proxy.
+func (p *endpointPool) URL() string {
+	return "http://" + p.listener.Addr().String() + p.path
+}
+
+func (p *endpointPool) Close() error {
+	close(p.closeCh)
+	return p.server.Close()
+}
+
+// ServeHTTP implements the http.Handler interface
+func (p *endpointPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if subtle.ConstantTimeCompare([]byte(r.URL.Path), []byte(p.path)) != 1 {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := p.forward(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+// forward sends the request to the healthy endpoints in priority order and
+// falls back to the unhealthy ones if none of them succeeds.
+func (p *endpointPool) forward(body []byte) ([]byte, error) {
+	var errs []string
+	for _, e := range p.candidates() {
+		resp, err := p.send(e, body)
+		if err == nil {
+			return resp, nil
+		}
+		e.setHealthy(false)
+		errs = append(errs, fmt.Sprintf("%s: %v", e.url, err))
+	}
+	return nil, fmt.Errorf("all the endpoints failed: %s", strings.Join(errs, ", "))
+}
+
+func (p *endpointPool) candidates() []*endpoint {
+	res := make([]*endpoint, len(p.endpoints))
+	copy(res, p.endpoints)
+
+	// stable sort keeps the priority order between endpoints with the same health
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].isHealthy() && !res[j].isHealthy()
+	})
+	return res
+}
+
+// send makes the http request to the endpoint and returns an error if the
+// endpoint should not be used for this request.
+func (p *endpointPool) send(e *endpoint, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, fmt.Errorf("rate limited")
+	}
+	if resp.StatusCode >= 500 {
+		return nil, fmt.Errorf("status code %d", resp.StatusCode)
+	}
+	if isRateLimited(data) {
+		return nil, fmt.Errorf("rate limited")
+	}
+	return data, nil
+}
+
+func isRateLimited(data []byte) bool {
+	var resp struct {
+		Error *struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return false
+	}
+	return resp.Error != nil && resp.Error.Code == rateLimitCode
+}
+
+func (p *endpointPool) runHealthCheck(interval time.Duration) {
+	for {
+		select {
+		case <-time.After(interval):
+			p.healthCheck()
+		case <-p.closeCh:
+			return
+		}
+	}
+}
+
+// healthCheck queries the head of every endpoint and marks as unhealthy the
+// ones that are unreachable or more than 'maxBlockLag' blocks behind.
+func (p *endpointPool) healthCheck() {
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+
+			head, err := p.blockNumber(e)
+
+			e.lock.Lock()
+			e.healthy = err == nil
+			e.head = head
+			e.lock.Unlock()
+		}(e)
+	}
+	wg.Wait()
+
+	var highest uint64
+	for _, e := range p.endpoints {
+		if e.isHealthy() && e.head > highest {
+			highest = e.head
+		}
+	}
+	for _, e := range p.endpoints {
+		e.lock.Lock()
+		if e.healthy && e.head+p.maxBlockLag < highest {
+			e.healthy = false
+		}
+		e.lock.Unlock()
+	}
+}
+
+func (p *endpointPool) blockNumber(e *endpoint) (uint64, error) {
+	data, err := p.send(e, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`))
+	if err != nil {
+		return 0, err
+	}
+
+	var resp struct {
+		Result string `json:"result"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimPrefix(resp.Result, "0x"), 16, 64)
+}
@@ -0,0 +1,196 @@
+package ethereum
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+)
+
+func TestEndpointPool_Failover(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	rateLimited := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+			return nil, &codec.ErrorObject{Code: rateLimitCode, Message: "limit exceeded"}
+		},
+	})
+
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+			return "0x10", nil
+		},
+	})
+
+	clt, err := newClientWithConfig(&clientConfig{
+		Hosts: []string{failing.URL, rateLimited.URL, srv.URL},
+	})
+	require.NoError(t, err)
+	defer clt.Close()
+
+	num, err := clt.Http().BlockNumber()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x10), num)
+}
+
+func TestEndpointPool_HealthCheck(t *testing.T) {
+	newNode := func(head *uint64) *httptest.Server {
+		return newTestRPCServer(t, map[string]testRPCHandler{
+			"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+				return fmt.Sprintf("0x%x", *head), nil
+			},
+		})
+	}
+
+	headA, headB := uint64(100), uint64(100)
+	nodeA, nodeB := newNode(&headA), newNode(&headB)
+
+	pool, err := newEndpointPool(&clientConfig{
+		Hosts:       []string{nodeA.URL, nodeB.URL},
+		MaxBlockLag: 5,
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	// both endpoints are healthy and the one with
+	// more priority is used first
+	require.Equal(t, nodeA.URL, pool.candidates()[0].url)
+
+	// the first endpoint lags behind
+	headB = 110
+	pool.healthCheck()
+
+	require.False(t, pool.endpoints[0].isHealthy())
+	require.True(t, pool.endpoints[1].isHealthy())
+	require.Equal(t, nodeB.URL, pool.candidates()[0].url)
+
+	// the first endpoint catches up
+	headA = 108
+	pool.healthCheck()
+
+	require.True(t, pool.endpoints[0].isHealthy())
+	require.Equal(t, nodeA.URL, pool.candidates()[0].url)
+}
+
+func TestEndpointPool_SecretPath(t *testing.T) {
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_blockNumber": func(params []json.RawMessage) (interface{}, error) {
+			return "0x10", nil
+		},
+	})
+
+	pool, err := newEndpointPool(&clientConfig{
+		Hosts:       []string{srv.URL},
+		BearerToken: "token",
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`
+
+	// the requests of other local processes do not know the path
+	for _, path := range []string{"/", "/other"} {
+		resp, err := http.Post("http://"+pool.listener.Addr().String()+path, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	}
+
+	resp, err := http.Post(pool.URL(), "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestEndpointPool_Headers(t *testing.T) {
+	var auth, apiKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		apiKey = r.Header.Get("X-Api-Key")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer srv.Close()
+
+	// the headers are sent directly to a single endpoint
+	// and through the pool to multiple endpoints
+	for _, hosts := range [][]string{{srv.URL}, {srv.URL, srv.URL}} {
+		auth, apiKey = "", ""
+
+		clt, err := newClientWithConfig(&clientConfig{
+			Hosts:       hosts,
+			Headers:     map[string]string{"X-Api-Key": "key"},
+			BearerToken: "token",
+		})
+		require.NoError(t, err)
+		require.Equal(t, len(hosts) > 1, clt.pool != nil)
+
+		_, err = clt.Http().BlockNumber()
+		require.NoError(t, err)
+		clt.Close()
+
+		require.Equal(t, "Bearer token", auth)
+		require.Equal(t, "key", apiKey)
+	}
+}
+
+func TestEndpointPool_MTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	// write the certificate of the server as the ca bundle
+	// and use the same certificate as the client one
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	cert := srv.TLS.Certificates[0]
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0600))
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+
+	// without client certificate the request fails
+	clt, err := newClientWithConfig(&clientConfig{
+		Hosts:  []string{srv.URL},
+		CAFile: caFile,
+	})
+	require.NoError(t, err)
+	defer clt.Close()
+
+	_, err = clt.Http().BlockNumber()
+	require.Error(t, err)
+
+	clt, err = newClientWithConfig(&clientConfig{
+		Hosts:          []string{srv.URL},
+		CAFile:         caFile,
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+	})
+	require.NoError(t, err)
+	defer clt.Close()
+
+	_, err = clt.Http().BlockNumber()
+	require.NoError(t, err)
+}
@@ -0,0 +1,92 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/umbracle/ethgo"
+)
+
+// linkReferences are the positions of the library placeholders in the
+// bytecode by source file and library name as returned by solc.
+type linkReferences map[string]map[string][]linkReference
+
+type linkReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// libraryPlaceholderLen is the length of a library placeholder in the
+// hex encoded bytecode, the same as the length of an address.
+const libraryPlaceholderLen = 40
+
+// libraryPlaceholder returns the placeholder of the library in the bytecode:
+// the first 17 bytes of the hash of its fully qualified name between __$ and $__.
+func libraryPlaceholder(name string) string {
+	return "__$" + hex.EncodeToString(ethgo.Keccak256([]byte(name))[:17]) + "$__"
+}
+
+// decodeLibraries decodes the addresses of the libraries by fully qualified name.
+func decodeLibraries(raw map[string]interface{}) (map[string]ethgo.Address, error) {
+	libraries := map[string]ethgo.Address{}
+	for name, val := range raw {
+		var addr ethgo.Address
+		if err := addr.UnmarshalText([]byte(val.(string))); err != nil {
+			return nil, fmt.Errorf("invalid address of library %s: %v", name, err)
+		}
+		libraries[name] = addr
+	}
+	return libraries, nil
+}
+
+// linkBytecode replaces the placeholders of the libraries in the hex encoded
+// bytecode with their addresses and decodes it. It fails if any of the
+// libraries referenced by the bytecode is not linked.
+func linkBytecode(object string, refs linkReferences, libraries map[string]ethgo.Address) ([]byte, error) {
+	code := strings.TrimPrefix(object, "0x")
+
+	unlinked := []string{}
+	for file, libs := range refs {
+		for name, offsets := range libs {
+			fullName := file + ":" + name
+			addr, ok := libraries[fullName]
+			if !ok {
+				unlinked = append(unlinked, fullName)
+				continue
+			}
+			for _, ref := range offsets {
+				start, end := ref.Start*2, (ref.Start+ref.Length)*2
+				if ref.Length != 20 || start < 0 || end > len(code) {
+					return nil, fmt.Errorf("invalid link reference of library %s at %d", fullName, ref.Start)
+				}
+				code = code[:start] + hex.EncodeToString(addr[:]) + code[end:]
+			}
+		}
+	}
+
+	// the artifacts without link references only include the placeholders
+	for name, addr := range libraries {
+		code = strings.ReplaceAll(code, libraryPlaceholder(name), hex.EncodeToString(addr[:]))
+	}
+
+	if len(unlinked) == 0 {
+		// placeholders of unknown libraries
+		for i := strings.Index(code, "__"); i != -1; i = strings.Index(code, "__") {
+			end := i + libraryPlaceholderLen
+			if end > len(code) {
+				end = len(code)
+			}
+			placeholder := code[i:end]
+			unlinked = append(unlinked, placeholder)
+			code = strings.ReplaceAll(code, placeholder, "")
+		}
+	}
+	if len(unlinked) != 0 {
+		sort.Strings(unlinked)
+		return nil, fmt.Errorf("the bytecode has unlinked libraries, set their addresses in libraries: %s", strings.Join(unlinked, ", "))
+	}
+
+	return hex.DecodeString(code)
+}
@@ -0,0 +1,72 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestLinkBytecode(t *testing.T) {
+	lib := ethgo.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	libHex := strings.ToLower(lib.String()[2:])
+
+	placeholder := libraryPlaceholder("src/Linked.sol:Math")
+	require.Equal(t, "__$", placeholder[:3])
+	require.Len(t, placeholder, libraryPlaceholderLen)
+
+	object := "0x6080" + placeholder + "6000"
+	refs := linkReferences{
+		"src/Linked.sol": {
+			"Math": []linkReference{{Start: 2, Length: 20}},
+		},
+	}
+	libraries := map[string]ethgo.Address{
+		"src/Linked.sol:Math": lib,
+	}
+
+	// with the link references
+	code, err := linkBytecode(object, refs, libraries)
+	require.NoError(t, err)
+	require.Equal(t, "6080"+libHex+"6000", hex.EncodeToString(code))
+
+	// only with the placeholders
+	code, err = linkBytecode(object, nil, libraries)
+	require.NoError(t, err)
+	require.Equal(t, "6080"+libHex+"6000", hex.EncodeToString(code))
+
+	// unlinked libraries
+	_, err = linkBytecode(object, refs, nil)
+	require.ErrorContains(t, err, "src/Linked.sol:Math")
+
+	_, err = linkBytecode(object, nil, nil)
+	require.ErrorContains(t, err, placeholder)
+
+	// invalid reference
+	refs["src/Linked.sol"]["Math"][0].Start = 100
+	_, err = linkBytecode(object, refs, libraries)
+	require.Error(t, err)
+
+	// bytecode without libraries
+	code, err = linkBytecode("0x6080", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x60, 0x80}, code)
+}
+
+func TestDecodeArtifact_LinkReferences(t *testing.T) {
+	refs := `{"src/Linked.sol": {"Math": [{"start": 2, "length": 20}]}}`
+
+	cases := []string{
+		// foundry
+		`{"abi": [], "bytecode": {"object": "0x6080", "linkReferences": ` + refs + `}}`,
+		// hardhat
+		`{"abi": [], "bytecode": "0x6080", "linkReferences": ` + refs + `}`,
+	}
+	for _, c := range cases {
+		artifact, err := decodeArtifact([]byte(c))
+		require.NoError(t, err)
+		require.Equal(t, []linkReference{{Start: 2, Length: 20}}, artifact.Bytecode.LinkReferences["src/Linked.sol"]["Math"])
+	}
+}
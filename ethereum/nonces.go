@@ -0,0 +1,93 @@
+package ethereum
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/umbracle/ethgo"
+)
+
+// nonceRetries is the number of times a transaction is sent again
+// with a fresh nonce after the node rejects the one assigned locally.
+const nonceRetries = 3
+
+// nonceManager assigns the nonces of the transactions sent by each account.
+// The nonces are tracked locally so that an account can have many transactions
+// in flight, and every account has its own lock so that different signers
+// send in parallel.
+type nonceManager struct {
+	lock     sync.Mutex
+	accounts map[ethgo.Address]*nonceAccount
+}
+
+type nonceAccount struct {
+	lock sync.Mutex
+
+	// next is the nonce assigned to the next transaction. It is only
+	// valid if synced is true, otherwise it is queried from the node.
+	next   uint64
+	synced bool
+}
+
+func newNonceManager() *nonceManager {
+	return &nonceManager{
+		accounts: map[ethgo.Address]*nonceAccount{},
+	}
+}
+
+func (n *nonceManager) account(addr ethgo.Address) *nonceAccount {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	acct, ok := n.accounts[addr]
+	if !ok {
+		acct = &nonceAccount{}
+		n.accounts[addr] = acct
+	}
+	return acct
+}
+
+// send assigns the next nonce of the account and calls 'sendFn' with it. The
+// nonce is only consumed if 'sendFn' succeeds. The account is resynchronised with
+// the 'pending' nonce returned by 'nonceFn' the first time it is used and after
+// any failure. If the node rejects the nonce, the transaction is sent again.
+func (n *nonceManager) send(addr ethgo.Address, nonceFn func() (uint64, error), sendFn func(nonce uint64) error) error {
+	acct := n.account(addr)
+
+	acct.lock.Lock()
+	defer acct.lock.Unlock()
+
+	for i := 0; ; i++ {
+		if !acct.synced {
+			nonce, err := nonceFn()
+			if err != nil {
+				return err
+			}
+			acct.next = nonce
+			acct.synced = true
+		}
+
+		err := sendFn(acct.next)
+		if err == nil {
+			acct.next++
+			return nil
+		}
+
+		acct.synced = false
+		if !isNonceError(err) || i == nonceRetries {
+			return err
+		}
+	}
+}
+
+// isNonceError returns true if the node rejected a transaction
+// because its nonce is out of sync with the state of the account.
+func isNonceError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, str := range []string{"nonce too low", "nonce too high", "invalid nonce", "replacement transaction underpriced"} {
+		if strings.Contains(msg, str) {
+			return true
+		}
+	}
+	return false
+}
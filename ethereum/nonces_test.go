@@ -0,0 +1,108 @@
+package ethereum
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestNonceManager_InFlight(t *testing.T) {
+	n := newNonceManager()
+
+	syncs := 0
+	nonceFn := func() (uint64, error) {
+		syncs++
+		return 5, nil
+	}
+
+	// the nonces are assigned locally without waiting for the
+	// previous transactions to be included
+	var nonces []uint64
+	for i := 0; i < 3; i++ {
+		err := n.send(ethgo.Address{0x1}, nonceFn, func(nonce uint64) error {
+			nonces = append(nonces, nonce)
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	require.Equal(t, []uint64{5, 6, 7}, nonces)
+	require.Equal(t, 1, syncs)
+
+	// a failed send resyncs the account on the next one
+	err := n.send(ethgo.Address{0x1}, nonceFn, func(nonce uint64) error {
+		return fmt.Errorf("insufficient funds")
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, syncs)
+
+	err = n.send(ethgo.Address{0x1}, nonceFn, func(nonce uint64) error {
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, syncs)
+}
+
+func TestNonceManager_Resync(t *testing.T) {
+	n := newNonceManager()
+
+	// another client sends transactions from the same account
+	pending := uint64(0)
+	nonceFn := func() (uint64, error) {
+		return pending, nil
+	}
+
+	require.NoError(t, n.send(ethgo.Address{0x1}, nonceFn, func(nonce uint64) error {
+		return nil
+	}))
+
+	pending = 10
+
+	var nonces []uint64
+	err := n.send(ethgo.Address{0x1}, nonceFn, func(nonce uint64) error {
+		nonces = append(nonces, nonce)
+		if nonce < pending {
+			return fmt.Errorf("nonce too low: next nonce %d, tx nonce %d", pending, nonce)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 10}, nonces)
+}
+
+func TestNonceManager_Parallel(t *testing.T) {
+	n := newNonceManager()
+
+	nonceFn := func() (uint64, error) {
+		return 0, nil
+	}
+
+	var lock sync.Mutex
+	nonces := map[ethgo.Address][]uint64{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		addr := ethgo.Address{byte(i % 4)}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := n.send(addr, nonceFn, func(nonce uint64) error {
+				lock.Lock()
+				nonces[addr] = append(nonces[addr], nonce)
+				lock.Unlock()
+				return nil
+			})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// every account gets a contiguous set of nonces
+	for _, list := range nonces {
+		require.Equal(t, []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, list)
+	}
+}
@@ -2,9 +2,13 @@ package ethereum
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const defaultHost = "http://localhost:8545"
@@ -16,12 +20,120 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     defaultHost,
-				Description: "The host of the Ethereum node. Defaults to '" + defaultHost + "'.",
+				Description: "The host of the Ethereum node. It can be an http(s) or websocket (ws, wss) url or the path to an IPC socket. Websocket and IPC hosts wait for transactions with subscriptions instead of polling. Defaults to '" + defaultHost + "'.",
+			},
+			"hosts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The list of endpoints of the Ethereum node ordered by priority. Requests fail over to the next endpoint on connection errors, 5xx responses and rate limits. Takes precedence over 'host'.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"health_check_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The interval in seconds at which the heads of the 'hosts' endpoints are compared. Zero disables the periodic health check. Defaults to 30.",
+			},
+			"max_block_lag": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of blocks an endpoint in 'hosts' can lag behind the highest head before it is skipped. Defaults to 5.",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Static headers sent with every request to the node (i.e. API keys).",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"basic_auth": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The credentials of the node if it uses http basic authentication.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The basic authentication username.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The basic authentication password.",
+						},
+					},
+				},
+			},
+			"bearer_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ETHEREUM_BEARER_TOKEN", nil),
+				Description: "The token sent as 'Authorization: Bearer' to the node. It can also be sourced from the 'ETHEREUM_BEARER_TOKEN' environment variable.",
+			},
+			"ca_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to a PEM encoded CA bundle used to verify the node instead of the system certificates.",
+			},
+			"client_cert_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key_file"},
+				Description:  "The path to the PEM encoded client certificate for mTLS.",
+			},
+			"client_key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_cert_file"},
+				Description:  "The path to the PEM encoded client key for mTLS.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The url of the http proxy used to reach the node.",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The timeout in seconds of each request to the node. Zero means no timeout.",
+			},
+			"signer": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Named signers that the resources use with 'signer_name' so that the keys are not passed as arguments.",
+				Elem: &schema.Resource{
+					Schema: providerSignerSchema(),
+				},
+			},
+			"chain_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The expected chain id of the node. If set, the provider fails if the node returns a different chain id.",
+			},
+			"confirmations": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of blocks on top of the one that includes a transaction to wait before considering it final. Defaults to 0.",
 			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
 			"ethereum_eoa":                datasourceEoa(),
+			"ethereum_accounts":           datasourceAccounts(),
 			"ethereum_block":              datasourceBlock(),
 			"ethereum_ens":                datasourceENS(),
 			"ethereum_event":              datasourceEvent(),
@@ -36,16 +148,86 @@ func Provider() *schema.Provider {
 			"ethereum_transaction":         TransactionResource(),
 			"ethereum_contract_deployment": ContractDeploymentResource(),
 			"ethereum_eoa":                 EOAResource(),
+			"ethereum_canonical_contracts": CanonicalContractsResource(),
+			"ethereum_proxy":               ProxyResource(),
 		},
 	}
 
+	// the client of the previous configuration of the provider is closed
+	// to stop the listener and the health check of its endpoint pool.
+	var (
+		lock   sync.Mutex
+		active *client
+	)
 	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		client, err := newClient(d.Get("host").(string))
+		client, err := configureClient(d)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		if active != nil {
+			active.Close()
+		}
+		active = client
 		return client, nil
 	}
 
 	return provider
 }
+
+// configureClient creates the client with the configuration of the provider.
+func configureClient(d *schema.ResourceData) (*client, error) {
+	config := &clientConfig{
+		Hosts:               []string{d.Get("host").(string)},
+		HealthCheckInterval: time.Duration(d.Get("health_check_interval").(int)) * time.Second,
+		MaxBlockLag:         uint64(d.Get("max_block_lag").(int)),
+		BearerToken:         d.Get("bearer_token").(string),
+		CAFile:              d.Get("ca_file").(string),
+		ClientCertFile:      d.Get("client_cert_file").(string),
+		ClientKeyFile:       d.Get("client_key_file").(string),
+		ProxyURL:            d.Get("proxy_url").(string),
+		RequestTimeout:      time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		Headers:             map[string]string{},
+	}
+	for k, v := range d.Get("headers").(map[string]interface{}) {
+		config.Headers[k] = v.(string)
+	}
+	if v, ok := d.GetOk("basic_auth"); ok {
+		basicAuth := v.([]interface{})[0].(map[string]interface{})
+		config.BasicAuthUsername = basicAuth["username"].(string)
+		config.BasicAuthPassword = basicAuth["password"].(string)
+	}
+	if hosts, ok := d.GetOk("hosts"); ok {
+		config.Hosts = []string{}
+		for _, host := range hosts.([]interface{}) {
+			config.Hosts = append(config.Hosts, host.(string))
+		}
+	}
+
+	client, err := newClientWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	client.confirmations = uint64(d.Get("confirmations").(int))
+
+	if client.signers, err = decodeProviderSigners(d.Get("signer").([]interface{})); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	if expected, ok := d.GetOk("chain_id"); ok {
+		chainID, err := client.getChainID()
+		if err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to get chain id: %v", err)
+		}
+		if !chainID.IsInt64() || chainID.Int64() != int64(expected.(int)) {
+			client.Close()
+			return nil, fmt.Errorf("chain id mismatch: expected %d but the node returned %s", expected.(int), chainID)
+		}
+	}
+	return client, nil
+}
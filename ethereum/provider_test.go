@@ -1,10 +1,14 @@
 package ethereum
 
 import (
+	"context"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
 )
 
 var testAccProviders map[string]*schema.Provider
@@ -23,3 +27,47 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("devnet not reachable")
 	}
 }
+
+func TestAccProvider_ChainIDMismatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "ethereum" {
+					chain_id = 999999
+				}
+
+				data "ethereum_gas_price" "gas_price" {}
+				`,
+				ExpectError: regexp.MustCompile("chain id mismatch"),
+			},
+		},
+	})
+}
+
+func TestProvider_ConfigureClosesClient(t *testing.T) {
+	srv := newTestRPCServer(t, map[string]testRPCHandler{})
+
+	p := Provider()
+	configure := func() *client {
+		d := schema.TestResourceDataRaw(t, p.Schema, map[string]interface{}{
+			"hosts": []interface{}{srv.URL, srv.URL},
+		})
+		meta, diags := p.ConfigureContextFunc(context.Background(), d)
+		require.False(t, diags.HasError())
+		return meta.(*client)
+	}
+
+	first := configure()
+	second := configure()
+	defer second.Close()
+
+	// the endpoint pool of the previous configuration is stopped
+	_, err := http.Post(first.pool.URL(), "application/json", nil)
+	require.Error(t, err)
+
+	_, err = http.Post(second.pool.URL(), "application/json", nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,322 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+	"github.com/umbracle/ethgo/wallet"
+	"github.com/umbracle/fastrlp"
+)
+
+const (
+	// remoteSignerJSONRPC signs with the eth_signTransaction
+	// endpoint (i.e. Clef or a node with unlocked accounts).
+	remoteSignerJSONRPC = "jsonrpc"
+
+	// remoteSignerWeb3Signer signs the hash of the
+	// transaction with the Web3Signer eth1 api.
+	remoteSignerWeb3Signer = "web3signer"
+)
+
+// remoteSigner signs the transactions with an external
+// signing service that holds the key of the account.
+type remoteSigner struct {
+	url     string
+	typ     string
+	address ethgo.Address
+	client  *http.Client
+}
+
+func newRemoteSigner(url, typ string, address ethgo.Address) *remoteSigner {
+	return &remoteSigner{
+		url:     strings.TrimSuffix(url, "/"),
+		typ:     typ,
+		address: address,
+		client:  http.DefaultClient,
+	}
+}
+
+func (r *remoteSigner) Address() ethgo.Address {
+	return r.address
+}
+
+func (r *remoteSigner) SignTransaction(ctx context.Context, txn *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	if r.typ == remoteSignerWeb3Signer {
+		return r.signWeb3Signer(ctx, txn, chainID)
+	}
+	return r.signJSONRPC(ctx, txn, chainID)
+}
+
+// signJSONRPC signs the transaction with eth_signTransaction which
+// returns the signed transaction ready to be broadcast.
+func (r *remoteSigner) signJSONRPC(ctx context.Context, txn *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	var out json.RawMessage
+	if err := r.call(ctx, "eth_signTransaction", &out, signTransactionArgs(r.address, txn, chainID)); err != nil {
+		return nil, err
+	}
+	return decodeSignedTransaction(out, txn, chainID, r.address)
+}
+
+// call sends a jsonrpc request to the signer until the context is done.
+// The websocket and ipc signers are closed to abort the request.
+func (r *remoteSigner) call(ctx context.Context, method string, out interface{}, params ...interface{}) error {
+	if !isHTTPHost(r.url) {
+		client, err := jsonrpc.NewClient(r.url)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- client.Call(method, out, params...)
+		}()
+		select {
+		case err := <-errCh:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+	data, err := r.post(ctx, r.url, body)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Result json.RawMessage    `json:"result"`
+		Error  *codec.ErrorObject `json:"error"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	return json.Unmarshal(resp.Result, out)
+}
+
+// post sends the body to the signer and returns the body of a successful response.
+func (r *remoteSigner) post(ctx context.Context, url string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status code %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+// signTransactionArgs returns the unsigned transaction as the json object
+// of eth_signTransaction. It is also the input of the command signers.
+func signTransactionArgs(from ethgo.Address, txn *ethgo.Transaction, chainID *big.Int) map[string]interface{} {
+	args := map[string]interface{}{
+		"from":    from,
+		"gas":     fmt.Sprintf("0x%x", txn.Gas),
+		"nonce":   fmt.Sprintf("0x%x", txn.Nonce),
+		"chainId": fmt.Sprintf("0x%x", chainID),
+		"data":    "0x" + hex.EncodeToString(txn.Input),
+	}
+	if txn.To != nil {
+		args["to"] = txn.To
+	}
+	if txn.Value != nil {
+		args["value"] = fmt.Sprintf("0x%x", txn.Value)
+	}
+	if txn.Type == ethgo.TransactionDynamicFee {
+		args["maxFeePerGas"] = fmt.Sprintf("0x%x", txn.MaxFeePerGas)
+		args["maxPriorityFeePerGas"] = fmt.Sprintf("0x%x", txn.MaxPriorityFeePerGas)
+	} else {
+		args["gasPrice"] = fmt.Sprintf("0x%x", txn.GasPrice)
+	}
+	if txn.Type != ethgo.TransactionLegacy {
+		args["type"] = fmt.Sprintf("0x%x", uint8(txn.Type))
+		if len(txn.AccessList) != 0 {
+			args["accessList"] = txn.AccessList
+		}
+	}
+	return args
+}
+
+// decodeSignedTransaction decodes the output of an external signer, either the
+// raw encoded transaction or an object with it ({raw, tx}). It checks that the
+// signature is for the unsigned transaction and from the expected account.
+func decodeSignedTransaction(out []byte, txn *ethgo.Transaction, chainID *big.Int, from ethgo.Address) ([]byte, error) {
+	str := strings.TrimSpace(string(out))
+	if strings.HasPrefix(str, "{") {
+		var obj struct {
+			Raw string `json:"raw"`
+		}
+		if err := json.Unmarshal([]byte(str), &obj); err != nil {
+			return nil, fmt.Errorf("failed to decode signed transaction: %v", err)
+		}
+		str = obj.Raw
+	}
+	str = strings.Trim(str, "\"")
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %v", err)
+	}
+
+	signed := &ethgo.Transaction{}
+	if err := signed.UnmarshalRLP(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %v", err)
+	}
+	recovery := new(big.Int).SetBytes(signed.V)
+	if signed.Type == ethgo.TransactionLegacy {
+		// EIP-155
+		recovery.Sub(recovery, big.NewInt(35))
+		recovery.Sub(recovery, new(big.Int).Mul(chainID, big.NewInt(2)))
+	}
+	if recovery.Sign() < 0 || recovery.Cmp(big.NewInt(1)) > 0 {
+		return nil, fmt.Errorf("signed transaction is not replay protected")
+	}
+
+	sig := make([]byte, 65)
+	copy(sig[32-len(signed.R):32], signed.R)
+	copy(sig[64-len(signed.S):64], signed.S)
+	sig[64] = byte(recovery.Uint64())
+
+	// the recovered address only matches if the signer did
+	// not modify any of the fields of the transaction
+	addr, err := wallet.Ecrecover(ethgo.Keccak256(signingPayload(txn, chainID)), sig)
+	if err != nil {
+		return nil, err
+	}
+	if addr != from {
+		return nil, fmt.Errorf("signed transaction does not match the transaction from %s", from)
+	}
+	return raw, nil
+}
+
+// signWeb3Signer signs the payload of the transaction with the Web3Signer
+// eth1 api and adds the signature to the transaction.
+func (r *remoteSigner) signWeb3Signer(ctx context.Context, txn *ethgo.Transaction, chainID *big.Int) ([]byte, error) {
+	payload := signingPayload(txn, chainID)
+
+	body, err := json.Marshal(map[string]string{
+		"data": "0x" + hex.EncodeToString(payload),
+	})
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.post(ctx, r.url+"/api/v1/eth1/sign/"+r.address.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	str := strings.Trim(strings.TrimSpace(string(data)), "\"")
+	sig, err := hex.DecodeString(strings.TrimPrefix(str, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %v", err)
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("expected a signature of 65 bytes but %d found", len(sig))
+	}
+
+	// the recovery id is returned either as 0/1 or 27/28
+	recovery := sig[64]
+	if recovery >= 27 {
+		recovery -= 27
+	}
+	sig[64] = recovery
+
+	// the signature must belong to the account of the signer
+	addr, err := wallet.Ecrecover(ethgo.Keccak256(payload), sig)
+	if err != nil {
+		return nil, err
+	}
+	if addr != r.address {
+		return nil, fmt.Errorf("signature is from %s instead of %s", addr, r.address)
+	}
+
+	v := uint64(recovery)
+	if txn.Type == ethgo.TransactionLegacy {
+		// EIP-155
+		v += 35 + chainID.Uint64()*2
+	}
+	txn.R = bytes.TrimLeft(sig[:32], "\x00")
+	txn.S = bytes.TrimLeft(sig[32:64], "\x00")
+	txn.V = new(big.Int).SetUint64(v).Bytes()
+
+	return txn.MarshalRLPTo(nil)
+}
+
+// signingPayload returns the encoding of the transaction whose hash is signed.
+// Legacy transactions without chain id are not replay protected (pre-EIP-155).
+func signingPayload(txn *ethgo.Transaction, chainID *big.Int) []byte {
+	a := fastrlp.DefaultArenaPool.Get()
+	defer fastrlp.DefaultArenaPool.Put(a)
+
+	v := a.NewArray()
+	if txn.Type != ethgo.TransactionLegacy {
+		v.Set(a.NewBigInt(chainID))
+	}
+	v.Set(a.NewUint(txn.Nonce))
+	if txn.Type == ethgo.TransactionDynamicFee {
+		v.Set(a.NewBigInt(txn.MaxPriorityFeePerGas))
+		v.Set(a.NewBigInt(txn.MaxFeePerGas))
+	} else {
+		v.Set(a.NewUint(txn.GasPrice))
+	}
+	v.Set(a.NewUint(txn.Gas))
+	if txn.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes(txn.To[:]))
+	}
+	v.Set(a.NewBigInt(txn.Value))
+	v.Set(a.NewCopyBytes(txn.Input))
+
+	if txn.Type == ethgo.TransactionLegacy {
+		if chainID != nil {
+			// EIP-155
+			v.Set(a.NewBigInt(chainID))
+			v.Set(a.NewUint(0))
+			v.Set(a.NewUint(0))
+		}
+	} else {
+		accessList, _ := txn.AccessList.MarshalRLPWith(a)
+		v.Set(accessList)
+	}
+
+	dst := v.MarshalTo(nil)
+	if txn.Type != ethgo.TransactionLegacy {
+		dst = append([]byte{byte(txn.Type)}, dst...)
+	}
+	return dst
+}
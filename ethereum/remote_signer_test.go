@@ -0,0 +1,159 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+func testRemoteSignerTxns() []*ethgo.Transaction {
+	return []*ethgo.Transaction{
+		{
+			Type:     ethgo.TransactionLegacy,
+			To:       &ethgo.Address{0x1},
+			Value:    big.NewInt(100),
+			Gas:      21000,
+			GasPrice: 10,
+			Nonce:    1,
+		},
+		{
+			Type:     ethgo.TransactionAccessList,
+			To:       &ethgo.Address{0x1},
+			Gas:      30000,
+			GasPrice: 10,
+			Nonce:    3,
+			ChainID:  big.NewInt(5),
+			AccessList: ethgo.AccessList{
+				{Address: ethgo.Address{0x1}, Storage: []ethgo.Hash{{0x4}}},
+			},
+		},
+		{
+			Type:                 ethgo.TransactionDynamicFee,
+			Input:                []byte{0x1, 0x2},
+			Gas:                  100000,
+			Nonce:                2,
+			ChainID:              big.NewInt(5),
+			MaxFeePerGas:         big.NewInt(20),
+			MaxPriorityFeePerGas: big.NewInt(2),
+			AccessList: ethgo.AccessList{
+				{Address: ethgo.Address{0x2}, Storage: []ethgo.Hash{{0x3}}},
+			},
+		},
+	}
+}
+
+func TestRemoteSigner_Web3Signer(t *testing.T) {
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	// stand-in of the Web3Signer eth1 api
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.URL.Path, "/api/v1/eth1/sign/"))
+
+		var req struct {
+			Data string `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		data, err := hex.DecodeString(strings.TrimPrefix(req.Data, "0x"))
+		require.NoError(t, err)
+
+		sig, err := key.Sign(ethgo.Keccak256(data))
+		require.NoError(t, err)
+		sig[64] += 27
+
+		w.Write([]byte("0x" + hex.EncodeToString(sig)))
+	}))
+	defer srv.Close()
+
+	local := &keySigner{key: key}
+	remote := newRemoteSigner(srv.URL, remoteSignerWeb3Signer, key.Address())
+
+	chainID := big.NewInt(5)
+	for _, txn := range testRemoteSignerTxns() {
+		clone := *txn
+		expected, err := local.SignTransaction(context.Background(), &clone, chainID)
+		require.NoError(t, err)
+
+		raw, err := remote.SignTransaction(context.Background(), txn, chainID)
+		require.NoError(t, err)
+		require.Equal(t, expected, raw)
+	}
+
+	// the signature of a different account is rejected
+	remote = newRemoteSigner(srv.URL, remoteSignerWeb3Signer, ethgo.Address{0x1})
+	_, err = remote.SignTransaction(context.Background(), testRemoteSignerTxns()[0], chainID)
+	require.Error(t, err)
+}
+
+func TestRemoteSigner_JSONRPC(t *testing.T) {
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	local := &keySigner{key: key}
+	chainID := big.NewInt(5)
+
+	var expected *ethgo.Transaction
+
+	// stand-in of a signer with eth_signTransaction that checks
+	// the arguments and returns the transaction signed locally
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_signTransaction": func(params []json.RawMessage) (interface{}, error) {
+			var args map[string]interface{}
+			if err := json.Unmarshal(params[0], &args); err != nil {
+				return nil, err
+			}
+			require.Equal(t, strings.ToLower(key.Address().String()), strings.ToLower(args["from"].(string)))
+			require.Equal(t, "0x5", args["chainId"])
+			require.Equal(t, "0x"+hex.EncodeToString(expected.Input), args["data"])
+
+			clone := *expected
+			raw, err := local.SignTransaction(context.Background(), &clone, chainID)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"raw": "0x" + hex.EncodeToString(raw)}, nil
+		},
+	})
+
+	remote := newRemoteSigner(srv.URL, remoteSignerJSONRPC, key.Address())
+	for _, txn := range testRemoteSignerTxns() {
+		expected = txn
+
+		raw, err := remote.SignTransaction(context.Background(), txn, chainID)
+		require.NoError(t, err)
+
+		signed, err := local.SignTransaction(context.Background(), txn, chainID)
+		require.NoError(t, err)
+		require.Equal(t, signed, raw)
+	}
+}
+
+func TestRemoteSigner_Timeout(t *testing.T) {
+	// the signer does not answer until the end of the test
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	for _, typ := range []string{remoteSignerJSONRPC, remoteSignerWeb3Signer} {
+		remote := newRemoteSigner(srv.URL, typ, ethgo.Address{0x1})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		_, err := remote.SignTransaction(ctx, testRemoteSignerTxns()[0], big.NewInt(5))
+		cancel()
+		require.ErrorIs(t, err, context.DeadlineExceeded, typ)
+	}
+}
@@ -0,0 +1,141 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/umbracle/ethgo"
+)
+
+// minReplacementPercentage is the minimum fee increase that the
+// nodes accept to replace a pending transaction with the same nonce.
+const minReplacementPercentage = 10
+
+// feeBump replaces a transaction that is not included in a block after
+// 'Interval' with the same one with the fees increased by 'Percentage'.
+type feeBump struct {
+	Interval   time.Duration
+	Percentage uint64
+
+	// FeeCap is the maximum gas price (legacy and access list) or max fee per
+	// gas (dynamic fee) of the replacement transactions.
+	FeeCap *big.Int
+}
+
+// bumpFees increases the fees of the transaction. It returns false and leaves the
+// transaction unchanged if the cap does not allow for a valid replacement.
+func (f *feeBump) bumpFees(txn *ethgo.Transaction) bool {
+	if txn.Type == ethgo.TransactionDynamicFee {
+		maxFee, ok := bumpFee(txn.MaxFeePerGas, f.Percentage, f.FeeCap)
+		if !ok {
+			return false
+		}
+		tip, ok := bumpFee(txn.MaxPriorityFeePerGas, f.Percentage, maxFee)
+		if !ok {
+			return false
+		}
+		txn.MaxFeePerGas = maxFee
+		txn.MaxPriorityFeePerGas = tip
+		return true
+	}
+
+	gasPrice, ok := bumpFee(new(big.Int).SetUint64(txn.GasPrice), f.Percentage, f.FeeCap)
+	if !ok || !gasPrice.IsUint64() {
+		return false
+	}
+	txn.GasPrice = gasPrice.Uint64()
+	return true
+}
+
+// bumpFee increases the fee by the percentage without going over the cap (if any).
+// It returns false if the new fee is not enough to replace the transaction.
+func bumpFee(fee *big.Int, percentage uint64, cap *big.Int) (*big.Int, bool) {
+	next := increasePercentage(fee, percentage)
+	if cap != nil && next.Cmp(cap) > 0 {
+		next = new(big.Int).Set(cap)
+	}
+	if next.Cmp(fee) <= 0 || next.Cmp(increasePercentage(fee, minReplacementPercentage)) < 0 {
+		return nil, false
+	}
+	return next, true
+}
+
+// increasePercentage returns val * (100 + percentage) / 100 rounded up.
+func increasePercentage(val *big.Int, percentage uint64) *big.Int {
+	res := new(big.Int).Mul(val, new(big.Int).SetUint64(100+percentage))
+	res.Add(res, big.NewInt(99))
+	return res.Div(res, big.NewInt(100))
+}
+
+// waitForReceiptWithFeeBump waits for the receipt of the transaction and replaces
+// it with higher fees every interval of the fee bump. It returns the hash of the
+// transaction that is included in a block, either the original or a replacement.
+func (c *client) waitForReceiptWithFeeBump(ctx context.Context, chainID *big.Int, ethTxn *ethgo.Transaction, txn *transaction) (ethgo.Hash, *ethgo.Receipt, error) {
+	heads, stop := c.watchHeads(receiptPollInterval)
+	defer stop()
+
+	ticker := time.NewTicker(txn.FeeBump.Interval)
+	defer ticker.Stop()
+
+	// fees of each of the transactions sent
+	type fees struct {
+		maxFee, tip *big.Int
+	}
+	sent := map[ethgo.Hash]fees{
+		txn.Hashes[0]: {ethTxn.MaxFeePerGas, ethTxn.MaxPriorityFeePerGas},
+	}
+
+	// rejected is the last replacement rejected by the node
+	var rejected error
+
+	for {
+		// any of the transactions with the same nonce can be included
+		for _, hash := range txn.Hashes {
+			receipt, _ := c.httpClient.Eth().GetTransactionReceipt(hash)
+			if receipt != nil {
+				if txn.Type == ethgo.TransactionDynamicFee {
+					txn.MaxFeePerGas, txn.MaxPriorityFeePerGas = sent[hash].maxFee, sent[hash].tip
+				}
+				return hash, receipt, nil
+			}
+		}
+
+		select {
+		case <-heads:
+		case <-ticker.C:
+			if !txn.FeeBump.bumpFees(ethTxn) {
+				// the fees are already at the cap
+				continue
+			}
+			hash, err := c.signAndSend(ctx, txn.Signer, chainID, ethTxn)
+			if err != nil {
+				msg := strings.ToLower(err.Error())
+				switch {
+				case strings.Contains(msg, "nonce too low"):
+					// one of the previous transactions was included in the meantime
+					rejected = nil
+					continue
+				case strings.Contains(msg, "replacement transaction underpriced"):
+					// the node requires a higher increase than the
+					// bump, the fees are bumped again in the next interval
+					rejected = err
+					continue
+				}
+				return ethgo.Hash{}, nil, fmt.Errorf("failed to replace transaction %s: %v", txn.Hashes[0], err)
+			}
+			rejected = nil
+			txn.Hashes = append(txn.Hashes, hash)
+			sent[hash] = fees{ethTxn.MaxFeePerGas, ethTxn.MaxPriorityFeePerGas}
+
+		case <-ctx.Done():
+			hash := txn.Hashes[len(txn.Hashes)-1]
+			if rejected != nil {
+				return hash, nil, fmt.Errorf("transaction %s not included in a block (the last replacement was rejected: %v): %w", hash, rejected, ctx.Err())
+			}
+			return hash, nil, fmt.Errorf("transaction %s not included in a block: %w", hash, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+func TestFeeBump_BumpFees(t *testing.T) {
+	bump := &feeBump{Percentage: 20, FeeCap: big.NewInt(135)}
+
+	txn := &ethgo.Transaction{
+		Type:                 ethgo.TransactionDynamicFee,
+		MaxFeePerGas:         big.NewInt(100),
+		MaxPriorityFeePerGas: big.NewInt(10),
+	}
+
+	require.True(t, bump.bumpFees(txn))
+	require.Equal(t, big.NewInt(120), txn.MaxFeePerGas)
+	require.Equal(t, big.NewInt(12), txn.MaxPriorityFeePerGas)
+
+	// the max fee is capped but it is still a valid replacement
+	// for the tip, which is bumped by the full percentage
+	require.True(t, bump.bumpFees(txn))
+	require.Equal(t, big.NewInt(135), txn.MaxFeePerGas)
+	require.Equal(t, big.NewInt(15), txn.MaxPriorityFeePerGas)
+
+	// at the cap the transaction cannot be replaced
+	require.False(t, bump.bumpFees(txn))
+	require.Equal(t, big.NewInt(135), txn.MaxFeePerGas)
+
+	legacy := &ethgo.Transaction{
+		Type:     ethgo.TransactionLegacy,
+		GasPrice: 100,
+	}
+	require.True(t, bump.bumpFees(legacy))
+	require.Equal(t, uint64(120), legacy.GasPrice)
+
+	// the cap does not allow a 10% increase
+	legacy.GasPrice = 125
+	require.False(t, bump.bumpFees(legacy))
+	require.Equal(t, uint64(125), legacy.GasPrice)
+}
+
+func TestClient_WaitForReceiptWithFeeBump(t *testing.T) {
+	var lock sync.Mutex
+	var sent []ethgo.Hash
+
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+			var raw string
+			if err := json.Unmarshal(params[0], &raw); err != nil {
+				return nil, err
+			}
+			lock.Lock()
+			defer lock.Unlock()
+
+			hash := ethgo.BytesToHash(ethgo.Keccak256([]byte(raw)))
+			sent = append(sent, hash)
+			return hash.String(), nil
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			var hash ethgo.Hash
+			if err := json.Unmarshal(params[0], &hash); err != nil {
+				return nil, err
+			}
+			lock.Lock()
+			defer lock.Unlock()
+
+			// the second replacement is included
+			if len(sent) > 2 && hash == sent[2] {
+				return testReceipt(1, ethgo.Hash{0x1}), nil
+			}
+			return nil, nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+	signer := &keySigner{key: key}
+
+	chainID := big.NewInt(1)
+	ethTxn := &ethgo.Transaction{
+		Type:                 ethgo.TransactionDynamicFee,
+		ChainID:              chainID,
+		MaxFeePerGas:         big.NewInt(100),
+		MaxPriorityFeePerGas: big.NewInt(10),
+	}
+	hash, err := clt.signAndSend(context.Background(), signer, chainID, ethTxn)
+	require.NoError(t, err)
+
+	txn := &transaction{
+		Type:    ethgo.TransactionDynamicFee,
+		Signer:  signer,
+		Hashes:  []ethgo.Hash{hash},
+		FeeBump: &feeBump{Interval: 10 * time.Millisecond, Percentage: 10},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hash, receipt, err := clt.waitForReceiptWithFeeBump(ctx, chainID, ethTxn, txn)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+
+	require.Equal(t, sent[2], hash)
+	require.Equal(t, sent[:3], txn.Hashes)
+	require.Equal(t, big.NewInt(121), txn.MaxFeePerGas)
+	require.Equal(t, big.NewInt(13), txn.MaxPriorityFeePerGas)
+}
+
+func TestClient_WaitForReceiptWithFeeBump_Underpriced(t *testing.T) {
+	var lock sync.Mutex
+	var sent []ethgo.Hash
+	replacements := 0
+
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+			var raw string
+			if err := json.Unmarshal(params[0], &raw); err != nil {
+				return nil, err
+			}
+			lock.Lock()
+			defer lock.Unlock()
+
+			// the node rejects the first replacement
+			replacements++
+			if replacements == 2 {
+				return nil, fmt.Errorf("replacement transaction underpriced")
+			}
+			hash := ethgo.BytesToHash(ethgo.Keccak256([]byte(raw)))
+			sent = append(sent, hash)
+			return hash.String(), nil
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			var hash ethgo.Hash
+			if err := json.Unmarshal(params[0], &hash); err != nil {
+				return nil, err
+			}
+			lock.Lock()
+			defer lock.Unlock()
+
+			// the replacement sent after the rejected one is included
+			if len(sent) > 1 && hash == sent[1] {
+				return testReceipt(1, ethgo.Hash{0x1}), nil
+			}
+			return nil, nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+	signer := &keySigner{key: key}
+
+	chainID := big.NewInt(1)
+	ethTxn := &ethgo.Transaction{
+		Type:                 ethgo.TransactionDynamicFee,
+		ChainID:              chainID,
+		MaxFeePerGas:         big.NewInt(100),
+		MaxPriorityFeePerGas: big.NewInt(10),
+	}
+	hash, err := clt.signAndSend(context.Background(), signer, chainID, ethTxn)
+	require.NoError(t, err)
+
+	txn := &transaction{
+		Type:    ethgo.TransactionDynamicFee,
+		Signer:  signer,
+		Hashes:  []ethgo.Hash{hash},
+		FeeBump: &feeBump{Interval: 10 * time.Millisecond, Percentage: 10},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the rejected replacement is bumped again
+	hash, receipt, err := clt.waitForReceiptWithFeeBump(ctx, chainID, ethTxn, txn)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Equal(t, sent[1], hash)
+	require.Equal(t, big.NewInt(121), txn.MaxFeePerGas)
+}
+
+func TestClient_WaitForReceiptWithFeeBump_Rejected(t *testing.T) {
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+			return nil, fmt.Errorf("replacement transaction underpriced")
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			return nil, nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	chainID := big.NewInt(1)
+	ethTxn := &ethgo.Transaction{
+		Type:                 ethgo.TransactionDynamicFee,
+		ChainID:              chainID,
+		MaxFeePerGas:         big.NewInt(100),
+		MaxPriorityFeePerGas: big.NewInt(10),
+	}
+	txn := &transaction{
+		Type:    ethgo.TransactionDynamicFee,
+		Signer:  &keySigner{key: key},
+		Hashes:  []ethgo.Hash{{0x1}},
+		FeeBump: &feeBump{Interval: 10 * time.Millisecond, Percentage: 10},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// the rejection of the replacements is reported
+	_, _, err = clt.waitForReceiptWithFeeBump(ctx, chainID, ethTxn, txn)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "replacement transaction underpriced")
+}
@@ -0,0 +1,239 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/umbracle/ethgo"
+)
+
+func CanonicalContractsResource() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Deploy the canonical infrastructure contracts (Multicall3, the deterministic deployment proxy and CreateX) at their usual addresses if they are not deployed in the chain.",
+		Schema: map[string]*schema.Schema{
+			"contracts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The canonical contracts to deploy: 'deterministic_deployment_proxy', 'multicall3' and 'createx'. Defaults to all of them.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(canonicalContractNames, false),
+				},
+			},
+			"transactions": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The hex encoded pre-signed deployment transactions by contract name, published in the repositories of the contracts. The transactions of multicall3 (deployer 0x05f32B3cC3888453ff71B01135B34FF8e41263F2) and createx (deployer 0xeD456e05CaAb11d66C4c797dD6c1D6f9A7F352b5) are not built-in: without them, these contracts are only installed with their runtime code in development nodes (anvil_setCode or hardhat_setCode).",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"addresses": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The addresses of the contracts by name.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"deployed": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The contracts deployed by the resource. The rest were already deployed in the chain.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CreateContext: resourceCanonicalContractsCreate,
+		ReadContext:   resourceCanonicalContractsRead,
+		UpdateContext: resourceCanonicalContractsUpdate,
+		DeleteContext: resourceCanonicalContractsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+		},
+	}
+	for k, v := range transactionSignerSchema() {
+		resource.Schema[k] = v
+	}
+	return resource
+}
+
+// decodeCanonicalContractNames returns the canonical contracts of the resource.
+func decodeCanonicalContractNames(d *schema.ResourceData) []string {
+	raw := d.Get("contracts").([]interface{})
+	if len(raw) == 0 {
+		return canonicalContractNames
+	}
+	names := []string{}
+	for _, name := range raw {
+		names = append(names, name.(string))
+	}
+	return names
+}
+
+func resourceCanonicalContractsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	signer, err := decodeSigner(d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	chainID, err := client.getChainID()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	transactions := d.Get("transactions").(map[string]interface{})
+	for name := range transactions {
+		if _, ok := canonicalContracts[name]; !ok {
+			return diag.Errorf("canonical contract '%s' not found", name)
+		}
+	}
+
+	addresses := map[string]interface{}{}
+	deployed := []string{}
+	for _, name := range decodeCanonicalContractNames(d) {
+		contract := canonicalContracts[name]
+		addresses[name] = contract.Address.String()
+
+		found, err := client.hasCode(contract.Address)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found {
+			continue
+		}
+
+		raw := contract.Transaction
+		if val, ok := transactions[name]; ok {
+			raw = val.(string)
+		}
+		if raw == "" {
+			// without a pre-signed transaction, the code is installed
+			// directly in the development nodes (i.e. anvil or hardhat)
+			if err := installCanonicalContract(client, contract); err != nil {
+				return diag.Errorf("%s is not deployed and its code is only installed in development nodes (%v), set the pre-signed transaction of its deployer %s in transactions", name, err, contract.Deployer)
+			}
+		} else if err := deployCanonicalContract(ctx, client, signer, contract, raw); err != nil {
+			return diag.Errorf("failed to deploy %s: %v", name, err)
+		}
+		deployed = append(deployed, name)
+	}
+
+	d.SetId(chainID.String())
+	d.Set("addresses", addresses)
+	d.Set("deployed", deployed)
+	d.Set("signer_address", signer.Address().String())
+
+	return nil
+}
+
+// deployCanonicalContract funds the deployer of the pre-signed
+// transaction if required and broadcasts the transaction.
+func deployCanonicalContract(ctx context.Context, client *client, signer transactionSigner, contract *canonicalContract, raw string) error {
+	presigned, err := decodePresignedTransaction(raw, contract.Address)
+	if err != nil {
+		return err
+	}
+
+	nonce, err := client.httpClient.Eth().GetNonce(presigned.Sender, ethgo.Latest)
+	if err != nil {
+		return err
+	}
+	if nonce != presigned.Txn.Nonce {
+		return fmt.Errorf("the deployer %s was already used in this chain", presigned.Sender)
+	}
+
+	balance, err := client.httpClient.Eth().GetBalance(presigned.Sender, ethgo.Latest)
+	if err != nil {
+		return err
+	}
+	if missing := new(big.Int).Sub(presigned.cost(), balance); missing.Sign() > 0 {
+		txn := &transaction{
+			Type:          ethgo.TransactionLegacy,
+			To:            &presigned.Sender,
+			Value:         missing,
+			Signer:        signer,
+			Confirmations: client.confirmations,
+		}
+		if _, _, err := client.sendTransaction(ctx, txn); err != nil {
+			return fmt.Errorf("failed to fund the deployer %s: %v", presigned.Sender, err)
+		}
+	}
+
+	if _, _, err := client.sendRawTransaction(ctx, presigned.Raw); err != nil {
+		return err
+	}
+	return checkCanonicalContract(client, contract)
+}
+
+// installCanonicalContract sets the runtime code of the contract at its
+// address in a development node and checks the hash of the code.
+func installCanonicalContract(client *client, contract *canonicalContract) error {
+	if contract.Code == "" {
+		return fmt.Errorf("the code of the contract is not known")
+	}
+	if err := client.setCode(contract.Address, contract.Code); err != nil {
+		return err
+	}
+	return checkCanonicalContract(client, contract)
+}
+
+// checkCanonicalContract checks that the contract is deployed and,
+// if its code is known, that it is the one of the contract.
+func checkCanonicalContract(client *client, contract *canonicalContract) error {
+	code, err := client.httpClient.Eth().GetCode(contract.Address, ethgo.Latest)
+	if err != nil {
+		return err
+	}
+	buf, err := hex.DecodeString(strings.TrimPrefix(code, "0x"))
+	if err != nil {
+		return err
+	}
+	if len(buf) == 0 {
+		return fmt.Errorf("contract not found at %s after the deployment", contract.Address)
+	}
+	if contract.CodeHash == (ethgo.Hash{}) {
+		return nil
+	}
+	if hash := ethgo.BytesToHash(ethgo.Keccak256(buf)); hash != contract.CodeHash {
+		return fmt.Errorf("code hash %s at %s does not match the expected %s", hash, contract.Address, contract.CodeHash)
+	}
+	return nil
+}
+
+func resourceCanonicalContractsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	// the contracts are deployed again if they are not found (i.e. the devnet restarted)
+	for _, name := range decodeCanonicalContractNames(d) {
+		found, err := client.hasCode(canonicalContracts[name].Address)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !found {
+			d.SetId("")
+			return nil
+		}
+	}
+	return nil
+}
+
+func resourceCanonicalContractsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only the attributes used to decrypt the signer can be
+	// updated and they are stored in the state by Terraform.
+	return resourceCanonicalContractsRead(ctx, d, meta)
+}
+
+func resourceCanonicalContractsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
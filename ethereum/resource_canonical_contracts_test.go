@@ -0,0 +1,37 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCanonicalContracts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_eoa" "account" {
+					mnemonic = "test test test test test test test test test test test junk"
+				}
+
+				resource "ethereum_canonical_contracts" "contracts" {
+					signer = data.ethereum_eoa.account.signer
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ethereum_canonical_contracts.contracts", "addresses.deterministic_deployment_proxy", "0x4e59b44847b379578588920cA78FbF26c0B4956C"),
+					resource.TestCheckResourceAttr(
+						"ethereum_canonical_contracts.contracts", "addresses.multicall3", "0xcA11bde05977b3631167028862bE2a173976CA11"),
+					resource.TestCheckResourceAttr(
+						"ethereum_canonical_contracts.contracts", "addresses.createx", "0xba5Ed099633D3B313e4D5F7bdc1305d3c28ba5Ed"),
+					resource.TestCheckResourceAttrSet(
+						"ethereum_canonical_contracts.contracts", "deployed.#"),
+				),
+			},
+		},
+	})
+}
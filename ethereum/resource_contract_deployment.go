@@ -2,7 +2,6 @@ package ethereum
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,7 +10,7 @@ import (
 )
 
 func ContractDeploymentResource() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Description: "Deploy a contract.",
 		Schema: map[string]*schema.Schema{
 			"artifact": {
@@ -29,16 +28,40 @@ func ContractDeploymentResource() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
-			"signer": {
-				Type:        schema.TypeString,
+			"libraries": {
+				Type:        schema.TypeMap,
+				Optional:    true,
 				ForceNew:    true,
-				Required:    true,
-				Description: "The signer of the transaction. This is the private key of the wallet.",
+				Description: "The addresses of the libraries linked to the contract by fully qualified name (i.e. src/Math.sol:Math).",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"salt": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSalt,
+				Description:  "The hex encoded salt (up to 32 bytes) of a deterministic deployment with CREATE2 through the factory. The address of the contract only depends on the factory, the salt and the init code, and it is known at plan time. If the contract is already deployed at that address, it is not deployed again.",
+			},
+			"factory": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"salt"},
+				Description:  "The address of the CREATE2 factory. Defaults to the deterministic deployment proxy (" + deterministicDeploymentProxy + ") or to CreateX (" + createXFactory + ") with create3.",
+			},
+			"create3": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"salt"},
+				Description:  "Deploy with CREATE3 through a CreateX compatible factory (deployCreate3). The address of the contract only depends on the factory, the signer and the salt, so it is the same in every chain even if the constructor inputs are different. The first 21 bytes of the salt are replaced with the address of the signer and a zero byte, so that the salt is guarded and only the signer can deploy to that address.",
 			},
 			"hash": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The hash of the transaction that creates the contract",
+				Description: "The hash of the transaction that creates the contract. It is empty for deterministic deployments of contracts that were already deployed.",
 			},
 			"block_num": {
 				Type:        schema.TypeInt,
@@ -55,31 +78,77 @@ func ContractDeploymentResource() *schema.Resource {
 				Computed:    true,
 				Description: "The address of the deployed contract.",
 			},
+			"block_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hash of the block that includes the contract deployment.",
+			},
 		},
 		CreateContext: resourceContractDeploymentCreate,
 		ReadContext:   resourceContractDeploymentRead,
+		UpdateContext: resourceContractDeploymentUpdate,
 		DeleteContext: resourceContractDeploymentDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return contractDeploymentDiff(d, meta.(*client))
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+		},
+	}
+	for k, v := range transactionSignerSchema() {
+		resource.Schema[k] = v
+	}
+	for k, v := range transactionFeeSchema() {
+		resource.Schema[k] = v
+	}
+	for k, v := range transactionWaitSchema() {
+		resource.Schema[k] = v
+	}
+	for k, v := range transactionReplacementSchema() {
+		resource.Schema[k] = v
 	}
+	for k, v := range transactionSimulationSchema() {
+		resource.Schema[k] = v
+	}
+	resource.SchemaVersion = 1
+	resource.StateUpgraders = transactionStateUpgraders(resource)
+	return resource
 }
 
-func resourceContractDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	signer, err := hex.DecodeString(d.Get("signer").(string))
+// decodeContractDeployment builds the transaction that deploys the contract
+// from the attributes of the resource.
+func decodeContractDeployment(d resourceGetter, client *client) (*transaction, error) {
+	txn, _, err := decodeDeployment(d, client)
+	return txn, err
+}
+
+// decodeDeployment builds the transaction that deploys the contract and, for
+// deterministic deployments, it returns the address of the contract.
+func decodeDeployment(d resourceGetter, client *client) (*transaction, *ethgo.Address, error) {
+	signer, err := decodeSigner(d, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, nil, err
 	}
 
 	txn := &transaction{
 		Signer: signer,
 	}
+	if err := decodeTransactionFees(d, txn); err != nil {
+		return nil, nil, err
+	}
 
 	artifact, err := resolveContract(d.Get("artifact").(string))
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, nil, err
 	}
 
-	code, err := hex.DecodeString(artifact.Bytecode.Object[2:])
+	libraries, err := decodeLibraries(d.Get("libraries").(map[string]interface{}))
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, nil, err
+	}
+	code, err := linkBytecode(artifact.Bytecode.Object, artifact.Bytecode.LinkReferences, libraries)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if cons := artifact.Abi.Constructor; cons != nil {
@@ -87,7 +156,7 @@ func resourceContractDeploymentCreate(ctx context.Context, d *schema.ResourceDat
 		if rawInputs, ok := d.GetOk("input"); ok {
 			inputs, err = decodeInputs(rawInputs)
 			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to decode inputs: %v", err))
+				return nil, nil, fmt.Errorf("failed to decode inputs: %v", err)
 			}
 		} else {
 			inputs = []interface{}{}
@@ -95,45 +164,199 @@ func resourceContractDeploymentCreate(ctx context.Context, d *schema.ResourceDat
 
 		inputsBytecode, err := cons.Inputs.Encode(inputs)
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, nil, err
 		}
 		code = append(code, inputsBytecode...)
 	}
 
 	txn.Input = code
+	txn.Abi = artifact.Abi
 
+	val, ok := d.GetOk("salt")
+	if !ok {
+		return txn, nil, nil
+	}
+	salt, err := decodeSalt(val.(string))
+	if err != nil {
+		return nil, nil, err
+	}
+	create3 := d.Get("create3").(bool)
+
+	factory := ethgo.HexToAddress(deterministicDeploymentProxy)
+	if create3 {
+		factory = ethgo.HexToAddress(createXFactory)
+	}
+	if val, ok := d.GetOk("factory"); ok {
+		factory = ethgo.HexToAddress(val.(string))
+	}
+	txn.To = &factory
+
+	if create3 {
+		var addr ethgo.Address
+		if txn.Input, addr, err = create3Deployment(factory, signer.Address(), salt, code); err != nil {
+			return nil, nil, err
+		}
+		return txn, &addr, nil
+	}
+
+	// the factory deploys the init code that follows the salt
+	addr := create2Address(factory, salt, ethgo.Keccak256(code))
+	txn.Input = append(salt[:], code...)
+
+	return txn, &addr, nil
+}
+
+// contractDeploymentDiff sets the address of deterministic deployments at plan
+// time and simulates the deployment unless the contract is already deployed.
+func contractDeploymentDiff(d *schema.ResourceDiff, client *client) error {
+	if _, ok := d.GetOk("salt"); ok && d.Id() == "" && d.GetRawConfig().IsWhollyKnown() {
+		_, addr, err := decodeDeployment(d, client)
+		if err != nil {
+			return err
+		}
+		if err := d.SetNew("contract_address", addr.String()); err != nil {
+			return err
+		}
+		deployed, err := client.hasCode(*addr)
+		if err != nil {
+			return err
+		}
+		if deployed {
+			// nothing is sent to the network
+			return nil
+		}
+	}
+	return simulateTransactionDiff(d, client, decodeContractDeployment)
+}
+
+func resourceContractDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client)
-	hash, receipt, err := client.sendTransaction(txn)
+
+	txn, addr, err := decodeDeployment(d, client)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(hash.String())
+	decodeTransactionWait(d, client, txn)
+	if err := decodeTransactionReplacement(d, txn); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if addr != nil {
+		// deterministic deployments are identified by the address of the
+		// contract since it might have been deployed by someone else
+		deployed, err := client.hasCode(*addr)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if deployed {
+			setExistingDeployment(d, *addr, txn)
+			return nil
+		}
+		if deployed, err := client.hasCode(*txn.To); err != nil {
+			return diag.FromErr(err)
+		} else if !deployed {
+			return diag.Errorf("factory %s is not deployed", txn.To)
+		}
+	}
+
+	hash, receipt, err := client.sendTransaction(ctx, txn)
+	if err != nil {
+		if addr != nil {
+			// the contract was deployed in the meantime
+			if deployed, _ := client.hasCode(*addr); deployed {
+				setExistingDeployment(d, *addr, txn)
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	contractAddress := receipt.ContractAddress
+	if addr != nil {
+		deployed, err := client.hasCode(*addr)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !deployed {
+			return diag.Errorf("contract not found at %s after the deployment %s", addr, hash)
+		}
+		contractAddress = *addr
+		d.SetId(addr.String())
+	} else {
+		d.SetId(hash.String())
+	}
 	d.Set("hash", hash.String())
 	d.Set("gas_used", receipt.GasUsed)
-	d.Set("contract_address", receipt.ContractAddress.String())
+	d.Set("contract_address", contractAddress.String())
 	d.Set("block_num", int(receipt.BlockNumber))
+	d.Set("block_hash", receipt.BlockHash.String())
+	setTransactionFees(d, txn)
+	setTransactionHashes(d, txn)
+	d.Set("signer_address", txn.Signer.Address().String())
 
 	return nil
 }
 
+// setExistingDeployment stores a deterministic deployment whose
+// contract was already deployed and no transaction was sent.
+func setExistingDeployment(d *schema.ResourceData, addr ethgo.Address, txn *transaction) {
+	d.SetId(addr.String())
+	d.Set("contract_address", addr.String())
+	d.Set("signer_address", txn.Signer.Address().String())
+
+	// the computed lists are empty instead of unknown in the next plans
+	d.Set("hashes", []string{})
+	if _, ok := d.GetOk("access_list"); !ok {
+		d.Set("access_list", []interface{}{})
+	}
+}
+
 func resourceContractDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client)
 
+	if _, ok := d.GetOk("salt"); ok {
+		// deterministic deployments are tracked by the code at the address
+		deployed, err := client.hasCode(ethgo.HexToAddress(d.Id()))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !deployed {
+			d.SetId("")
+		}
+		return nil
+	}
+
 	hash := d.Id()
-	receipt, err := client.Http().GetTransactionReceipt(ethgo.HexToHash(hash))
+	receipt, included, err := client.includedReceipt(ethgo.HexToHash(hash), uint64(d.Get("block_num").(int)), ethgo.HexToHash(d.Get("block_hash").(string)))
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if !included {
+		// the deployment is not part of the canonical chain anymore,
+		// remove it from the state so that it is deployed again.
+		d.SetId("")
+		return nil
+	}
+	if receipt == nil {
+		// the deployment is still in the same block
+		return nil
+	}
 
-	d.SetId(hash)
-	d.Set("hash", hash)
 	d.Set("gas_used", receipt.GasUsed)
 	d.Set("contract_address", receipt.ContractAddress.String())
+	d.Set("block_num", int(receipt.BlockNumber))
+	d.Set("block_hash", receipt.BlockHash.String())
 
 	return nil
 }
 
+func resourceContractDeploymentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only the attributes that do not modify the deployment can be
+	// updated and they are stored in the state by Terraform.
+	return resourceContractDeploymentRead(ctx, d, meta)
+}
+
 func resourceContractDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
@@ -1,9 +1,17 @@
 package ethereum
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
 )
 
 func checkContractDeployed() resource.TestCheckFunc {
@@ -19,6 +27,48 @@ func checkContractDeployed() resource.TestCheckFunc {
 	)
 }
 
+func TestContractDeployment_Baseline(t *testing.T) {
+	config := map[string]interface{}{
+		"signer":   "0x1",
+		"artifact": "../testcases/out:Hello",
+	}
+	// state of a deployment of the provider versions before the fee
+	// attributes after the upgrade of the state (access_list)
+	state := &terraform.InstanceState{
+		ID: "0x2",
+		Attributes: map[string]string{
+			"id":               "0x2",
+			"signer":           "0x1",
+			"artifact":         "../testcases/out:Hello",
+			"hash":             "0x2",
+			"contract_address": "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+			"gas_used":         "21000",
+			"block_num":        "1",
+			"block_hash":       "0x3",
+			"access_list.#":    "0",
+		},
+	}
+	testPlanDiff(t, ContractDeploymentResource(), state, config)
+}
+
+func TestSetExistingDeployment(t *testing.T) {
+	r := ContractDeploymentResource()
+	config := map[string]interface{}{
+		"signer":   "0x1",
+		"artifact": "../testcases/out:Hello",
+		"salt":     "0x01",
+	}
+
+	addr := ethgo.HexToAddress("0x74B73aC4158B64004F8379966052b215E2A5fc77")
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	setExistingDeployment(d, addr, &transaction{Signer: &nodeSigner{address: addr}})
+
+	// the existing deployment has no changes in the next plan
+	diff := testPlanDiff(t, r, d.State(), config)
+	require.Empty(t, diff.Attributes)
+}
+
 func TestAccContractDeployment_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
@@ -77,3 +127,134 @@ func TestAccContractDeployment_Inputs(t *testing.T) {
 		},
 	})
 }
+
+func TestAccContractDeployment_Create2(t *testing.T) {
+	// a new salt for every run since the contract is deployed only once
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	require.NoError(t, err)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "ethereum_eoa" "account" {
+						mnemonic = "test test test test test test test test test test test junk"
+					}
+
+					resource "ethereum_contract_deployment" "deploy" {
+						signer   = data.ethereum_eoa.account.signer
+						artifact = "../testcases/out:Hello"
+						salt     = "0x%[1]s"
+
+						input = [
+						  "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"
+						]
+					}
+
+					// the contract is already deployed at the same address
+					resource "ethereum_contract_deployment" "existing" {
+						signer   = data.ethereum_eoa.account.signer
+						artifact = "../testcases/out:Hello"
+						salt     = "0x%[1]s"
+
+						input = [
+						  "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"
+						]
+
+						depends_on = [ethereum_contract_deployment.deploy]
+					}
+					`, hex.EncodeToString(salt)),
+				Check: resource.ComposeTestCheckFunc(
+					checkContractDeployed(),
+					resource.TestCheckResourceAttrPair(
+						"ethereum_contract_deployment.deploy", "contract_address",
+						"ethereum_contract_deployment.existing", "contract_address"),
+					resource.TestCheckNoResourceAttr(
+						"ethereum_contract_deployment.existing", "hash"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContractDeployment_Create3(t *testing.T) {
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	require.NoError(t, err)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "ethereum_eoa" "account" {
+						mnemonic = "test test test test test test test test test test test junk"
+					}
+
+					resource "ethereum_contract_deployment" "deploy" {
+						signer   = data.ethereum_eoa.account.signer
+						artifact = "../testcases/out:Hello"
+						salt     = "0x%s"
+						create3  = true
+
+						input = [
+						  "0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5"
+						]
+					}
+					`, hex.EncodeToString(salt)),
+				Check: checkContractDeployed(),
+			},
+		},
+	})
+}
+
+func TestAccContractDeployment_Libraries(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "ethereum_eoa" "account" {
+						mnemonic = "test test test test test test test test test test test junk"
+					}
+
+					resource "ethereum_contract_deployment" "deploy" {
+						signer = data.ethereum_eoa.account.signer
+
+						artifact = "../testcases/out:Calculator"
+					}
+					`,
+				ExpectError: regexp.MustCompile("unlinked libraries.*src/Linked.sol:Math"),
+			},
+			{
+				Config: `
+					data "ethereum_eoa" "account" {
+						mnemonic = "test test test test test test test test test test test junk"
+					}
+
+					resource "ethereum_contract_deployment" "math" {
+						signer = data.ethereum_eoa.account.signer
+
+						artifact = "../testcases/out:Math"
+					}
+
+					resource "ethereum_contract_deployment" "deploy" {
+						signer = data.ethereum_eoa.account.signer
+
+						artifact = "../testcases/out:Calculator"
+
+						libraries = {
+							"src/Linked.sol:Math" = ethereum_contract_deployment.math.contract_address
+						}
+					}
+					`,
+				Check: checkContractDeployed(),
+			},
+		},
+	})
+}
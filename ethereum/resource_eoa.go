@@ -2,10 +2,21 @@ package ethereum
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/keystore"
 	"github.com/umbracle/ethgo/wallet"
 )
 
@@ -13,6 +24,20 @@ func EOAResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "Create a new EOA wallet.",
 		Schema: map[string]*schema.Schema{
+			"mnemonic_words": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntInSlice([]int{12, 24}),
+				Description:  "The number of words (12 or 24) of a BIP-39 mnemonic to generate. The wallet is the first account of the mnemonic. If not set, a random private key is generated instead. For imported mnemonics, it is the number of words of the mnemonic.",
+			},
+			"mnemonic": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The generated mnemonic of the wallet.",
+			},
 			"address": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -21,36 +46,419 @@ func EOAResource() *schema.Resource {
 			"signer": {
 				Type:        schema.TypeString,
 				Computed:    true,
+				Sensitive:   true,
 				Description: "The signer of the wallet. This is the private key of the wallet.",
 			},
+			"funding": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Send funds to the wallet when it is created. The funds are only sent once, later changes do not send funds nor replace the wallet.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"signer": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ExactlyOneOf: []string{"funding.0.signer", "funding.0.signer_name"},
+							Description:  "The private key of the account that sends the funds.",
+						},
+						"signer_name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ExactlyOneOf: []string{"funding.0.signer", "funding.0.signer_name"},
+							Description:  "The name of a signer configured in the provider that sends the funds. Alternative to signer.",
+						},
+						"amount": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The amount sent to the wallet (i.e. '1 ether' or '100 gwei').",
+						},
+					},
+				},
+			},
+			"funding_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hash of the transaction that funds the wallet.",
+			},
+			"sweep_to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The address that receives the balance of the wallet (minus the fees) when the resource is destroyed.",
+			},
+			"keystore_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of an encrypted keystore (V3) file where the private key is written. The file is written again if it is deleted or it does not contain the key of the wallet, and it is kept when the resource is destroyed.",
+			},
+			"keystore_password_env": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				RequiredWith:  []string{"keystore_path"},
+				ConflictsWith: []string{"keystore_password_file"},
+				Description:   "The name of the environment variable with the password of the keystore.",
+			},
+			"keystore_password_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				RequiredWith:  []string{"keystore_path"},
+				ConflictsWith: []string{"keystore_password_env"},
+				Description:   "The path to the file with the password of the keystore.",
+			},
+			"keystore_scrypt_n": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultScryptN,
+				ValidateFunc: validateScryptN,
+				Description:  "The scrypt cost parameter (N) of the keystore. It must be a power of two. Defaults to 262144.",
+			},
+			"keystore_scrypt_p": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultScryptP,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The scrypt parallelization parameter (P) of the keystore. Defaults to 1.",
+			},
 		},
 		CreateContext: resourceEOACreate,
 		ReadContext:   resourceEOARead,
+		UpdateContext: resourceEOAUpdate,
 		DeleteContext: resourceEOADelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEOAImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultCreateTimeout),
+		},
 	}
 }
 
 func resourceEOACreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	key, err := wallet.GenerateKey()
+	var (
+		key      *wallet.Key
+		mnemonic string
+		err      error
+	)
+
+	if words, ok := d.GetOk("mnemonic_words"); ok {
+		// 128 bits of entropy for 12 words and 256 bits for 24 words
+		entropy, err := bip39.NewEntropy(words.(int) / 3 * 32)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if mnemonic, err = bip39.NewMnemonic(entropy); err != nil {
+			return diag.FromErr(err)
+		}
+		if key, err = deriveKey(mnemonic, "", accountDerivationPath(defaultDerivationPath, 0)); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if key, err = wallet.GenerateKey(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setEOAKey(d, key, mnemonic); err != nil {
+		return diag.FromErr(err)
+	}
+	if path, ok := d.GetOk("keystore_path"); ok {
+		if err := writeEOAKeystore(d, path.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if val, ok := d.GetOk("funding"); ok {
+		hash, err := fundEOA(ctx, meta.(*client), val.([]interface{})[0].(map[string]interface{}), key.Address())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("funding_hash", hash.String())
+	}
+	return nil
+}
+
+func resourceEOARead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	path, ok := d.GetOk("keystore_path")
+	if !ok {
+		return nil
+	}
+
+	// the keystore is written again if it was deleted or replaced
+	address, found, err := readKeystoreAddress(path.(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if !found || address != ethgo.HexToAddress(d.Get("address").(string)) {
+		d.Set("keystore_path", "")
+	}
+	return nil
+}
+
+func resourceEOAUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChanges("keystore_path", "keystore_password_env", "keystore_password_file", "keystore_scrypt_n", "keystore_scrypt_p") {
+		return nil
+	}
+
+	// the keystore is written in the new path before removing the old one
+	oldPath, newPath := d.GetChange("keystore_path")
+	if newPath.(string) != "" {
+		if err := writeEOAKeystore(d, newPath.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if oldPath.(string) != "" && oldPath.(string) != newPath.(string) {
+		if err := removeKeystore(oldPath.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceEOADelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if to, ok := d.GetOk("sweep_to"); ok {
+		priv, err := hex.DecodeString(d.Get("signer").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		signer, err := newKeySigner(priv)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := sweepEOA(ctx, meta.(*client), signer, ethgo.HexToAddress(to.(string))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	// the keystore is kept since it may be the only copy of the key
+	// once the resource is removed from the state
+	return nil
+}
 
+// resourceEOAImport imports a wallet from either its hex
+// encoded private key or its mnemonic (the import id).
+func resourceEOAImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	key, mnemonic, err := decodeEOAImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if mnemonic != "" {
+		d.Set("mnemonic_words", len(strings.Fields(mnemonic)))
+	}
+	if err := setEOAKey(d, key, mnemonic); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// decodeEOAImportID returns the key of the wallet of the import id. A mnemonic
+// is a list of words while a private key is a single hex encoded value.
+func decodeEOAImportID(id string) (*wallet.Key, string, error) {
+	if words := strings.Fields(id); len(words) > 1 {
+		mnemonic := strings.Join(words, " ")
+		key, err := deriveKey(mnemonic, "", accountDerivationPath(defaultDerivationPath, 0))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to import mnemonic: %v", err)
+		}
+		return key, mnemonic, nil
+	}
+
+	priv, err := decodePrivateKey(id)
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := wallet.NewWalletFromPrivKey(priv)
+	if err != nil {
+		return nil, "", err
+	}
+	return key, "", nil
+}
+
+func setEOAKey(d *schema.ResourceData, key *wallet.Key, mnemonic string) error {
 	priv, err := key.MarshallPrivateKey()
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 
 	d.SetId(key.Address().String())
 	d.Set("address", key.Address().String())
 	d.Set("signer", hex.EncodeToString(priv))
+	d.Set("mnemonic", mnemonic)
 	return nil
 }
 
-func resourceEOARead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// fundEOA sends the amount of the funding block to the wallet.
+func fundEOA(ctx context.Context, client *client, funding map[string]interface{}, to ethgo.Address) (ethgo.Hash, error) {
+	var signer transactionSigner
+	if name := funding["signer_name"].(string); name != "" {
+		var ok bool
+		if signer, ok = client.signers[name]; !ok {
+			return ethgo.Hash{}, fmt.Errorf("signer '%s' is not configured in the provider", name)
+		}
+	} else {
+		priv, err := decodePrivateKey(funding["signer"].(string))
+		if err != nil {
+			return ethgo.Hash{}, err
+		}
+		if signer, err = newKeySigner(priv); err != nil {
+			return ethgo.Hash{}, err
+		}
+	}
+
+	amount, err := parseEtherValue(funding["amount"].(string))
+	if err != nil {
+		return ethgo.Hash{}, fmt.Errorf("failed to parse funding amount '%s': %v", funding["amount"], err)
+	}
+
+	txn := &transaction{
+		Type:          ethgo.TransactionLegacy,
+		To:            &to,
+		Value:         amount,
+		Signer:        signer,
+		Confirmations: client.confirmations,
+	}
+	hash, _, err := client.sendTransaction(ctx, txn)
+	if err != nil {
+		return ethgo.Hash{}, fmt.Errorf("failed to fund %s: %v", to, err)
+	}
+	return hash, nil
+}
+
+// sweepEOA sends the balance of the wallet to the address. The fees are paid
+// from the balance so the transaction is priced upfront and nothing is left.
+func sweepEOA(ctx context.Context, client *client, signer transactionSigner, to ethgo.Address) error {
+	from := signer.Address()
+
+	balance, err := client.httpClient.Eth().GetBalance(from, ethgo.Latest)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of %s: %v", from, err)
+	}
+	if balance.Sign() == 0 {
+		return nil
+	}
+
+	gasPrice, err := client.httpClient.Eth().GasPrice()
+	if err != nil {
+		return fmt.Errorf("failed to get gas price: %v", err)
+	}
+	gas, err := client.estimateGas(callArgs(from, &transaction{To: &to}))
+	if err != nil {
+		return fmt.Errorf("gas estimation failed: %v", err)
+	}
+
+	value := new(big.Int).Sub(balance, new(big.Int).Mul(new(big.Int).SetUint64(gas), new(big.Int).SetUint64(gasPrice)))
+	if value.Sign() <= 0 {
+		// the balance does not cover the fees
+		return nil
+	}
+
+	txn := &transaction{
+		Type:     ethgo.TransactionLegacy,
+		To:       &to,
+		Value:    value,
+		Signer:   signer,
+		GasLimit: gas,
+		GasPrice: gasPrice,
+	}
+	if _, _, err := client.sendTransaction(ctx, txn); err != nil {
+		return fmt.Errorf("failed to sweep %s: %v", from, err)
+	}
 	return nil
 }
 
-func resourceEOADelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+const (
+	// defaultScryptN and defaultScryptP are the scrypt
+	// parameters of the keystores written by geth.
+	defaultScryptN = 1 << 18
+	defaultScryptP = 1
+)
+
+func validateScryptN(i interface{}, k string) ([]string, []error) {
+	n, ok := i.(int)
+	if !ok || n < 2 || n&(n-1) != 0 {
+		return nil, []error{fmt.Errorf("expected %s to be a power of two greater than 1, got %v", k, i)}
+	}
+	return nil, nil
+}
+
+// writeEOAKeystore writes the private key of the wallet to the keystore
+// file encrypted with the password and scrypt parameters of the resource.
+func writeEOAKeystore(d *schema.ResourceData, path string) error {
+	password, err := readPassword(d.Get("keystore_password_env").(string), d.Get("keystore_password_file").(string))
+	if err != nil {
+		return err
+	}
+	if password == "" {
+		return fmt.Errorf("a password is required to encrypt the keystore (keystore_password_env or keystore_password_file)")
+	}
+	priv, err := hex.DecodeString(d.Get("signer").(string))
+	if err != nil {
+		return err
+	}
+	return writeKeystore(path, priv, password, d.Get("keystore_scrypt_n").(int), d.Get("keystore_scrypt_p").(int))
+}
+
+// writeKeystore encrypts the private key in a V3 keystore file. The address
+// and the id of the key are included since most wallets require them.
+func writeKeystore(path string, priv []byte, password string, scryptN, scryptP int) error {
+	key, err := wallet.NewWalletFromPrivKey(priv)
+	if err != nil {
+		return err
+	}
+	data, err := keystore.EncryptV3(priv, password, scryptN, scryptP)
+	if err != nil {
+		return err
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	obj["address"] = hex.EncodeToString(key.Address().Bytes())
+	if obj["id"], err = newUUID(); err != nil {
+		return err
+	}
+
+	if data, err = json.Marshal(obj); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write keystore '%s': %v", path, err)
+	}
 	return nil
 }
+
+// readKeystoreAddress returns the address of a keystore file
+// or false if the file does not exist.
+func readKeystoreAddress(path string) (ethgo.Address, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ethgo.Address{}, false, nil
+	}
+	if err != nil {
+		return ethgo.Address{}, false, fmt.Errorf("failed to read keystore '%s': %v", path, err)
+	}
+
+	var obj struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return ethgo.Address{}, false, fmt.Errorf("failed to decode keystore '%s': %v", path, err)
+	}
+	return ethgo.HexToAddress(obj.Address), true, nil
+}
+
+func removeKeystore(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove keystore '%s': %v", path, err)
+	}
+	return nil
+}
+
+// newUUID returns a random (version 4) uuid.
+func newUUID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	buf[6] = buf[6]&0x0f | 0x40
+	buf[8] = buf[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", buf[0:4], buf[4:6], buf[6:8], buf[8:10], buf[10:]), nil
+}
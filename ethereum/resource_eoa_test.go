@@ -1,9 +1,21 @@
 package ethereum
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/keystore"
+	"github.com/umbracle/ethgo/wallet"
 )
 
 func TestAccEOA_Resource(t *testing.T) {
@@ -23,6 +35,329 @@ func TestAccEOA_Resource(t *testing.T) {
 						"ethereum_eoa.account", "address"),
 				),
 			},
+			{
+				ResourceName:      "ethereum_eoa.account",
+				ImportState:       true,
+				ImportStateIdFunc: testAccEOAImportID("signer"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccEOA_ResourceMnemonic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "ethereum_eoa" "account" {
+						mnemonic_words = 24
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"ethereum_eoa.account", "signer"),
+					resource.TestCheckResourceAttrWith(
+						"ethereum_eoa.account", "mnemonic", func(value string) error {
+							if num := len(strings.Fields(value)); num != 24 {
+								return fmt.Errorf("expected 24 words but found %d", num)
+							}
+							return nil
+						}),
+				),
+			},
+			{
+				ResourceName:      "ethereum_eoa.account",
+				ImportState:       true,
+				ImportStateIdFunc: testAccEOAImportID("mnemonic"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccEOAImportID returns the secret of the wallet used to import it.
+func testAccEOAImportID(attr string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources["ethereum_eoa.account"]
+		if !ok {
+			return "", fmt.Errorf("ethereum_eoa.account not found")
+		}
+		return rs.Primary.Attributes[attr], nil
+	}
+}
+
+func TestDecodeEOAImportID(t *testing.T) {
+	// private key with and without prefix
+	for _, id := range []string{
+		"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+		"0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80",
+	} {
+		key, mnemonic, err := decodeEOAImportID(id)
+		require.NoError(t, err)
+		require.Empty(t, mnemonic)
+		require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", key.Address().String())
+	}
+
+	// mnemonic
+	key, mnemonic, err := decodeEOAImportID(" test test test test test test test test test test test  junk")
+	require.NoError(t, err)
+	require.Equal(t, "test test test test test test test test test test test junk", mnemonic)
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", key.Address().String())
+
+	// invalid mnemonic checksum
+	_, _, err = decodeEOAImportID("test test test test test test test test test test test test")
+	require.Error(t, err)
+}
+
+func TestEOAImport_Mnemonic(t *testing.T) {
+	r := EOAResource()
+
+	// mnemonics of any length are imported
+	for _, mnemonic := range []string{
+		"test test test test test test test test test test test junk",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent",
+	} {
+		d := r.Data(nil)
+		d.SetId(mnemonic)
+
+		res, err := resourceEOAImport(context.Background(), d, nil)
+		require.NoError(t, err)
+
+		// the imported wallet is not replaced without mnemonic_words in the config
+		diff := testPlanDiff(t, r, res[0].State(), map[string]interface{}{})
+		require.Nil(t, diff.Attributes["mnemonic_words"])
+	}
+}
+
+func TestEOA_FundingChange(t *testing.T) {
+	r := EOAResource()
+
+	funding := func(amount string) map[string]interface{} {
+		return map[string]interface{}{
+			"funding": []interface{}{
+				map[string]interface{}{
+					"signer_name": "deployer",
+					"amount":      amount,
+				},
+			},
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, funding("1 ether"))
+	d.SetId("0x1")
+
+	// the wallet with its key is kept when the funding changes or it is removed
+	testPlanDiff(t, r, d.State(), funding("2 ether"))
+	testPlanDiff(t, r, d.State(), map[string]interface{}{})
+
+	// or when it is added to an existing wallet
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("0x1")
+	testPlanDiff(t, r, d.State(), funding("1 ether"))
+}
+
+func TestEOADelete_KeepsKeystore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+
+	d := schema.TestResourceDataRaw(t, EOAResource().Schema, map[string]interface{}{
+		"keystore_path": path,
+	})
+	d.SetId("0x1")
+
+	// the keystore may be the only copy of the key after the destroy
+	require.False(t, resourceEOADelete(context.Background(), d, nil).HasError())
+	_, err := os.Stat(path)
+	require.NoError(t, err)
+}
+
+func TestAccEOA_ResourceKeystore(t *testing.T) {
+	keystorePath := filepath.Join(t.TempDir(), "keystore.json")
+	t.Setenv("TEST_KEYSTORE_PASSWORD", "password")
+
+	config := fmt.Sprintf(`
+		resource "ethereum_eoa" "account" {
+			keystore_path         = "%s"
+			keystore_password_env = "TEST_KEYSTORE_PASSWORD"
+			keystore_scrypt_n     = 1024
+		}
+	`, keystorePath)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			// the keystore is kept after the wallet is destroyed
+			_, err := os.Stat(keystorePath)
+			return err
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckEOAKeystore(keystorePath),
+			},
+			{
+				// the deleted keystore is written again
+				PreConfig: func() {
+					require.NoError(t, os.Remove(keystorePath))
+				},
+				Config: config,
+				Check:  testAccCheckEOAKeystore(keystorePath),
+			},
+		},
+	})
+}
+
+func testAccCheckEOAKeystore(path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["ethereum_eoa.account"]
+		if !ok {
+			return fmt.Errorf("ethereum_eoa.account not found")
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		priv, err := keystore.DecryptV3(data, "password")
+		if err != nil {
+			return err
+		}
+		key, err := wallet.NewWalletFromPrivKey(priv)
+		if err != nil {
+			return err
+		}
+		if key.Address().String() != rs.Primary.Attributes["address"] {
+			return fmt.Errorf("keystore of %s found instead of %s", key.Address(), rs.Primary.Attributes["address"])
+		}
+		return nil
+	}
+}
+
+func TestWriteKeystore(t *testing.T) {
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+	priv, err := key.MarshallPrivateKey()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keystore.json")
+
+	_, found, err := readKeystoreAddress(path)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, writeKeystore(path, priv, "password", 1<<4, 2))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	decrypted, err := keystore.DecryptV3(data, "password")
+	require.NoError(t, err)
+	require.Equal(t, priv, decrypted)
+
+	var obj struct {
+		ID     string `json:"id"`
+		Crypto struct {
+			KDFParams struct {
+				N int `json:"n"`
+				P int `json:"p"`
+			} `json:"kdfparams"`
+		} `json:"crypto"`
+	}
+	require.NoError(t, json.Unmarshal(data, &obj))
+	require.Len(t, obj.ID, 36)
+	require.Equal(t, 1<<4, obj.Crypto.KDFParams.N)
+	require.Equal(t, 2, obj.Crypto.KDFParams.P)
+
+	address, found, err := readKeystoreAddress(path)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, key.Address(), address)
+
+	require.NoError(t, removeKeystore(path))
+	require.NoError(t, removeKeystore(path))
+}
+
+func TestValidateScryptN(t *testing.T) {
+	for _, n := range []int{2, 1024, defaultScryptN} {
+		_, errs := validateScryptN(n, "keystore_scrypt_n")
+		require.Empty(t, errs)
+	}
+	for _, n := range []int{0, 1, 1000} {
+		_, errs := validateScryptN(n, "keystore_scrypt_n")
+		require.NotEmpty(t, errs)
+	}
+}
+
+func TestAccEOA_ResourceFundingSweep(t *testing.T) {
+	target, err := wallet.GenerateKey()
+	require.NoError(t, err)
+
+	var account ethgo.Address
+	balanceOf := func(addr ethgo.Address) (string, error) {
+		clt, err := newClient(defaultHost)
+		if err != nil {
+			return "", err
+		}
+		defer clt.Close()
+
+		balance, err := clt.httpClient.Eth().GetBalance(addr, ethgo.Latest)
+		if err != nil {
+			return "", err
+		}
+		return balance.String(), nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "ethereum_eoa" "account" {
+					funding {
+						signer = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+						amount = "1 ether"
+					}
+					sweep_to = "%s"
+				}
+				`, target.Address()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"ethereum_eoa.account", "funding_hash"),
+					func(s *terraform.State) error {
+						account = ethgo.HexToAddress(s.RootModule().Resources["ethereum_eoa.account"].Primary.Attributes["address"])
+
+						balance, err := balanceOf(account)
+						if err != nil {
+							return err
+						}
+						if balance != "1000000000000000000" {
+							return fmt.Errorf("expected a balance of 1 ether but found %s", balance)
+						}
+						return nil
+					},
+				),
+			},
+		},
+		CheckDestroy: func(s *terraform.State) error {
+			// the whole balance is sent to the target
+			balance, err := balanceOf(account)
+			if err != nil {
+				return err
+			}
+			if balance != "0" {
+				return fmt.Errorf("expected an empty balance but found %s", balance)
+			}
+			if balance, err = balanceOf(target.Address()); err != nil {
+				return err
+			}
+			if balance == "0" {
+				return fmt.Errorf("the balance was not sent to the target")
+			}
+			return nil
 		},
 	})
 }
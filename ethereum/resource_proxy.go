@@ -0,0 +1,426 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+)
+
+const (
+	// proxyKindUUPS proxies are upgraded by the implementation (EIP-1822)
+	proxyKindUUPS = "uups"
+
+	// proxyKindTransparent proxies are upgraded by their admin
+	proxyKindTransparent = "transparent"
+)
+
+var (
+	// eip1967ImplementationSlot is the storage slot of the implementation
+	// of the proxy (bytes32(uint256(keccak256('eip1967.proxy.implementation')) - 1))
+	eip1967ImplementationSlot = ethgo.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+	// eip1967AdminSlot is the storage slot of the admin of the proxy
+	// (bytes32(uint256(keccak256('eip1967.proxy.admin')) - 1))
+	eip1967AdminSlot = ethgo.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+	// upgradeToAndCallSelector is the selector of upgradeToAndCall(address,bytes)
+	// of the UUPS implementations and of the admin of transparent proxies
+	upgradeToAndCallSelector = ethgo.Keccak256([]byte("upgradeToAndCall(address,bytes)"))[:4]
+
+	upgradeToAndCallType = abi.MustNewType("tuple(address implementation, bytes data)")
+
+	// upgradeAndCallSelector is the selector of upgradeAndCall(address,address,bytes)
+	// of the ProxyAdmin contracts that own the transparent proxies
+	upgradeAndCallSelector = ethgo.Keccak256([]byte("upgradeAndCall(address,address,bytes)"))[:4]
+
+	upgradeAndCallType = abi.MustNewType("tuple(address proxy, address implementation, bytes data)")
+)
+
+func ProxyResource() *schema.Resource {
+	resource := &schema.Resource{
+		Description: "Deploy an upgradeable ERC-1967 proxy (UUPS or transparent) for an implementation. A change of the implementation upgrades the proxy in place.",
+		Schema: map[string]*schema.Schema{
+			"artifact": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ABI artifact of the proxy contract to deploy (i.e. the ERC1967Proxy or the TransparentUpgradeableProxy of OpenZeppelin).",
+			},
+			"kind": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      proxyKindUUPS,
+				ValidateFunc: validation.StringInSlice([]string{proxyKindUUPS, proxyKindTransparent}, false),
+				Description:  "The kind of the proxy. It is either 'uups' (the implementation upgrades the proxy) or 'transparent' (the admin upgrades the proxy). Defaults to 'uups'.",
+			},
+			"implementation": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressAddressCase,
+				Description:      "The address of the implementation of the proxy. A change upgrades the proxy with upgradeToAndCall.",
+			},
+			"admin": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The initial admin of a transparent proxy, the owner of its ProxyAdmin for the OpenZeppelin 5 proxies. Defaults to the signer.",
+			},
+			"initializer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The typed function of the implementation called by the proxy on deployment (i.e. initialize(address)).",
+			},
+			"initializer_input": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"initializer"},
+				Description:  "The inputs of the initializer.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"upgrade_call": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The typed function of the new implementation called on every upgrade (i.e. a reinitializer). Without it, the proxy is upgraded without a call.",
+			},
+			"upgrade_call_input": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				RequiredWith: []string{"upgrade_call"},
+				Description:  "The inputs of the upgrade call.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"contract_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The address of the proxy.",
+			},
+			"admin_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The address in the EIP-1967 admin slot of the proxy, either the admin or the ProxyAdmin contract of a transparent proxy. It is empty for UUPS proxies.",
+			},
+			"hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hash of the transaction that deploys the proxy.",
+			},
+			"upgrade_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hash of the transaction of the last upgrade of the proxy.",
+			},
+		},
+		CreateContext: resourceProxyCreate,
+		ReadContext:   resourceProxyRead,
+		UpdateContext: resourceProxyUpdate,
+		DeleteContext: resourceProxyDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+			Update: schema.DefaultTimeout(defaultCreateTimeout),
+		},
+	}
+
+	// the upgrades are sent with the current signer and fees
+	for k, v := range transactionSignerSchema() {
+		resource.Schema[k] = updatableSchema(v)
+	}
+	for k, v := range transactionFeeSchema() {
+		resource.Schema[k] = updatableSchema(v)
+	}
+	// the access lists generated for the transactions are not stored
+	// since they are not valid for the next ones
+	resource.Schema["access_list"].Computed = false
+	for k, v := range transactionWaitSchema() {
+		resource.Schema[k] = v
+	}
+	return resource
+}
+
+// updatableSchema removes ForceNew from the attribute and its nested attributes.
+func updatableSchema(s *schema.Schema) *schema.Schema {
+	s.ForceNew = false
+	if elem, ok := s.Elem.(*schema.Resource); ok {
+		for _, v := range elem.Schema {
+			updatableSchema(v)
+		}
+	}
+	return s
+}
+
+// suppressAddressCase ignores the differences in the checksum of an address.
+func suppressAddressCase(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// encodeFunctionCall encodes the call to the typed function with the inputs.
+func encodeFunctionCall(function string, rawInputs interface{}) ([]byte, error) {
+	method, err := abi.NewMethod(function)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse function '%s': %v", function, err)
+	}
+	inputs, err := decodeInputs(rawInputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode inputs: %v", err)
+	}
+	buf, err := method.Encode(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to abi encode: %v", err)
+	}
+	return buf, nil
+}
+
+// decodeProxyTransaction returns a transaction of the proxy resource
+// with the signer and the fees but without destination or input.
+func decodeProxyTransaction(d *schema.ResourceData, client *client) (*transaction, error) {
+	signer, err := decodeSigner(d, client)
+	if err != nil {
+		return nil, err
+	}
+	txn := &transaction{
+		Signer: signer,
+	}
+	if err := decodeTransactionFees(d, txn); err != nil {
+		return nil, err
+	}
+	decodeTransactionWait(d, client, txn)
+	return txn, nil
+}
+
+// decodeProxyDeployment builds the transaction that deploys the proxy with
+// the constructor of the kind of the proxy:
+// - uups: constructor(address implementation, bytes data)
+// - transparent: constructor(address logic, address admin, bytes data)
+func decodeProxyDeployment(d *schema.ResourceData, client *client) (*transaction, error) {
+	txn, err := decodeProxyTransaction(d, client)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := resolveContract(d.Get("artifact").(string))
+	if err != nil {
+		return nil, err
+	}
+	code, err := linkBytecode(artifact.Bytecode.Object, artifact.Bytecode.LinkReferences, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var implementation ethgo.Address
+	if err := implementation.UnmarshalText([]byte(d.Get("implementation").(string))); err != nil {
+		return nil, fmt.Errorf("invalid implementation: %v", err)
+	}
+
+	data := []byte{}
+	if val, ok := d.GetOk("initializer"); ok {
+		if data, err = encodeFunctionCall(val.(string), d.Get("initializer_input")); err != nil {
+			return nil, err
+		}
+	}
+
+	inputs := []interface{}{implementation, data}
+	kind := d.Get("kind").(string)
+	if kind == proxyKindTransparent {
+		admin := txn.Signer.Address()
+		if val, ok := d.GetOk("admin"); ok {
+			if err := admin.UnmarshalText([]byte(val.(string))); err != nil {
+				return nil, fmt.Errorf("invalid admin: %v", err)
+			}
+		}
+		inputs = []interface{}{implementation, admin, data}
+	} else if _, ok := d.GetOk("admin"); ok {
+		return nil, fmt.Errorf("admin is only valid for transparent proxies")
+	}
+
+	cons := artifact.Abi.Constructor
+	if cons == nil || len(cons.Inputs.TupleElems()) != len(inputs) {
+		return nil, fmt.Errorf("the constructor of the proxy artifact is not the one of a %s proxy", kind)
+	}
+	inputsBytecode, err := cons.Inputs.Encode(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	txn.Input = append(code, inputsBytecode...)
+	txn.Abi = artifact.Abi
+	return txn, nil
+}
+
+// decodeProxyUpgrade builds the transaction that upgrades the proxy to the
+// new implementation. UUPS proxies are upgraded with the upgradeToAndCall
+// function of the implementation. Transparent proxies are upgraded either by
+// the admin directly or through the upgradeAndCall function of the
+// ProxyAdmin contract in the admin slot.
+func decodeProxyUpgrade(d *schema.ResourceData, client *client) (*transaction, error) {
+	txn, err := decodeProxyTransaction(d, client)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := ethgo.HexToAddress(d.Id())
+
+	var implementation ethgo.Address
+	if err := implementation.UnmarshalText([]byte(d.Get("implementation").(string))); err != nil {
+		return nil, fmt.Errorf("invalid implementation: %v", err)
+	}
+
+	data := []byte{}
+	if val, ok := d.GetOk("upgrade_call"); ok {
+		if data, err = encodeFunctionCall(val.(string), d.Get("upgrade_call_input")); err != nil {
+			return nil, err
+		}
+	}
+
+	to, input, err := proxyUpgradeCall(client, d.Get("kind").(string), proxy, txn.Signer.Address(), implementation, data)
+	if err != nil {
+		return nil, err
+	}
+	txn.To = &to
+	txn.Input = input
+	return txn, nil
+}
+
+// proxyUpgradeCall returns the destination and the input of the call that
+// upgrades the proxy. UUPS proxies and transparent proxies whose admin is the
+// signer are upgraded with upgradeToAndCall. Otherwise, the admin of the
+// transparent proxy must be a ProxyAdmin contract with upgradeAndCall.
+func proxyUpgradeCall(client *client, kind string, proxy, signer, implementation ethgo.Address, data []byte) (ethgo.Address, []byte, error) {
+	if kind == proxyKindTransparent {
+		admin, err := client.getStorageAddress(proxy, eip1967AdminSlot)
+		if err != nil {
+			return ethgo.Address{}, nil, err
+		}
+		if admin != signer {
+			// a call to an account that is not the signer does nothing
+			isContract, err := client.hasCode(admin)
+			if err != nil {
+				return ethgo.Address{}, nil, err
+			}
+			if !isContract {
+				return ethgo.Address{}, nil, fmt.Errorf("the admin %s of the proxy is an account and only it can upgrade the proxy", admin)
+			}
+			input, err := upgradeAndCallType.Encode([]interface{}{proxy, implementation, data})
+			if err != nil {
+				return ethgo.Address{}, nil, err
+			}
+			return admin, append(append([]byte{}, upgradeAndCallSelector...), input...), nil
+		}
+	}
+
+	input, err := upgradeToAndCallType.Encode([]interface{}{implementation, data})
+	if err != nil {
+		return ethgo.Address{}, nil, err
+	}
+	return proxy, append(append([]byte{}, upgradeToAndCallSelector...), input...), nil
+}
+
+// getStorageAddress returns the address stored in the slot of the contract.
+func (c *client) getStorageAddress(addr ethgo.Address, slot ethgo.Hash) (ethgo.Address, error) {
+	val, err := c.httpClient.Eth().GetStorageAt(addr, slot, ethgo.Latest)
+	if err != nil {
+		return ethgo.Address{}, fmt.Errorf("failed to get storage of %s: %v", addr, err)
+	}
+	return ethgo.BytesToAddress(val[12:]), nil
+}
+
+func resourceProxyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	txn, err := decodeProxyDeployment(d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash, receipt, err := client.sendTransaction(ctx, txn)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(receipt.ContractAddress.String())
+	d.Set("hash", hash.String())
+	d.Set("contract_address", receipt.ContractAddress.String())
+	d.Set("signer_address", txn.Signer.Address().String())
+
+	return resourceProxyRead(ctx, d, meta)
+}
+
+func resourceProxyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	proxy := ethgo.HexToAddress(d.Id())
+	deployed, err := client.hasCode(proxy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !deployed {
+		// the proxy is not part of the chain anymore
+		d.SetId("")
+		return nil
+	}
+
+	// the implementation is read from the proxy so that any upgrade
+	// done outside of Terraform shows up as a change of the implementation
+	implementation, err := client.getStorageAddress(proxy, eip1967ImplementationSlot)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	admin, err := client.getStorageAddress(proxy, eip1967AdminSlot)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("contract_address", proxy.String())
+	d.Set("implementation", implementation.String())
+	if admin == (ethgo.Address{}) {
+		d.Set("admin_address", "")
+	} else {
+		d.Set("admin_address", admin.String())
+	}
+	return nil
+}
+
+func resourceProxyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	// the rest of the attributes only apply to the next transactions
+	if d.HasChange("implementation") {
+		txn, err := decodeProxyUpgrade(d, client)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		hash, _, err := client.sendTransaction(ctx, txn)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to upgrade proxy %s: %v", d.Id(), err))
+		}
+		d.Set("upgrade_hash", hash.String())
+		d.Set("signer_address", txn.Signer.Address().String())
+
+		// a transaction that succeeds might not upgrade the proxy
+		// (i.e. the upgrade function of the implementation is a no-op)
+		implementation, err := client.getStorageAddress(ethgo.HexToAddress(d.Id()), eip1967ImplementationSlot)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if expected := ethgo.HexToAddress(d.Get("implementation").(string)); implementation != expected {
+			return diag.Errorf("proxy %s not upgraded by %s: the implementation is %s instead of %s", d.Id(), hash, implementation, expected)
+		}
+	}
+	return resourceProxyRead(ctx, d, meta)
+}
+
+func resourceProxyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the proxy cannot be removed from the chain
+	return nil
+}
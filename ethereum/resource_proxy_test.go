@@ -0,0 +1,176 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+)
+
+func TestProxy_Selectors(t *testing.T) {
+	require.Equal(t, "4f1ef286", hex.EncodeToString(upgradeToAndCallSelector))
+	require.Equal(t, "9623609d", hex.EncodeToString(upgradeAndCallSelector))
+}
+
+func TestEncodeFunctionCall(t *testing.T) {
+	buf, err := encodeFunctionCall("initialize(uint256)", []interface{}{"5"})
+	require.NoError(t, err)
+	require.Equal(t, "fe4b84df0000000000000000000000000000000000000000000000000000000000000005", hex.EncodeToString(buf))
+
+	_, err = encodeFunctionCall("initialize(", nil)
+	require.Error(t, err)
+}
+
+func TestProxyUpgradeCall(t *testing.T) {
+	proxy := ethgo.Address{0x1}
+	signer := ethgo.Address{0x2}
+	implementation := ethgo.Address{0x3}
+
+	var admin ethgo.Address
+	var adminCode string
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_getStorageAt": func(params []json.RawMessage) (interface{}, error) {
+			return ethgo.BytesToHash(admin[:]), nil
+		},
+		"eth_getCode": func(params []json.RawMessage) (interface{}, error) {
+			return adminCode, nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	// the implementation of a UUPS proxy upgrades it
+	to, input, err := proxyUpgradeCall(clt, proxyKindUUPS, proxy, signer, implementation, nil)
+	require.NoError(t, err)
+	require.Equal(t, proxy, to)
+	require.Equal(t, upgradeToAndCallSelector, input[:4])
+
+	// the signer is the admin of the transparent proxy
+	admin = signer
+	to, input, err = proxyUpgradeCall(clt, proxyKindTransparent, proxy, signer, implementation, nil)
+	require.NoError(t, err)
+	require.Equal(t, proxy, to)
+	require.Equal(t, upgradeToAndCallSelector, input[:4])
+
+	// the admin of the transparent proxy is a ProxyAdmin contract
+	admin = ethgo.Address{0x4}
+	adminCode = "0x6080"
+	to, input, err = proxyUpgradeCall(clt, proxyKindTransparent, proxy, signer, implementation, nil)
+	require.NoError(t, err)
+	require.Equal(t, admin, to)
+	require.Equal(t, upgradeAndCallSelector, input[:4])
+
+	// the admin of the transparent proxy is another account
+	adminCode = "0x"
+	_, _, err = proxyUpgradeCall(clt, proxyKindTransparent, proxy, signer, implementation, nil)
+	require.Error(t, err)
+}
+
+func TestProxy_AccessList(t *testing.T) {
+	config := map[string]interface{}{
+		"signer":         "0x1",
+		"artifact":       "../testcases/out:ERC1967Proxy",
+		"implementation": "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+	}
+	state := &terraform.InstanceState{
+		ID: "0x2",
+		Attributes: map[string]string{
+			"id":               "0x2",
+			"signer":           "0x1",
+			"artifact":         "../testcases/out:ERC1967Proxy",
+			"kind":             proxyKindUUPS,
+			"implementation":   "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+			"contract_address": "0x2",
+			"hash":             "0x3",
+		},
+	}
+
+	// the access list is not stored and it is not planned
+	diff := testPlanDiff(t, ProxyResource(), state, config)
+	require.Empty(t, diff.Attributes)
+}
+
+func testAccProxyConfig(kind, implementation string) string {
+	return fmt.Sprintf(`
+	data "ethereum_eoa" "account" {
+		mnemonic = "test test test test test test test test test test test junk"
+	}
+
+	resource "ethereum_contract_deployment" "v1" {
+		signer   = data.ethereum_eoa.account.signer
+		artifact = "../testcases/out:CounterV1"
+	}
+
+	resource "ethereum_contract_deployment" "v2" {
+		signer   = data.ethereum_eoa.account.signer
+		artifact = "../testcases/out:CounterV2"
+	}
+
+	resource "ethereum_proxy" "proxy" {
+		signer   = data.ethereum_eoa.account.signer
+		artifact = "%s"
+		kind     = "%s"
+
+		implementation    = ethereum_contract_deployment.%s.contract_address
+		initializer       = "initialize(uint256)"
+		initializer_input = ["5"]
+	}
+
+	data "ethereum_call" "version" {
+		artifact = "../testcases/out:CounterV2"
+		method   = "version"
+		to       = ethereum_proxy.proxy.contract_address
+
+		depends_on = [ethereum_proxy.proxy]
+	}
+	`, map[string]string{
+		proxyKindUUPS:        "../testcases/out:ERC1967Proxy",
+		proxyKindTransparent: "../testcases/out:TransparentUpgradeableProxy",
+	}[kind], kind, implementation)
+}
+
+func testAccProxy(t *testing.T, kind string) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProxyConfig(kind, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"ethereum_proxy.proxy", "hash"),
+					resource.TestCheckResourceAttrPair(
+						"ethereum_proxy.proxy", "implementation", "ethereum_contract_deployment.v1", "contract_address"),
+					resource.TestCheckResourceAttr(
+						"data.ethereum_call.version", "output.0", "1"),
+				),
+			},
+			{
+				// the proxy is upgraded in place
+				Config: testAccProxyConfig(kind, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"ethereum_proxy.proxy", "upgrade_hash"),
+					resource.TestCheckResourceAttrPair(
+						"ethereum_proxy.proxy", "implementation", "ethereum_contract_deployment.v2", "contract_address"),
+					resource.TestCheckResourceAttr(
+						"data.ethereum_call.version", "output.0", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProxy_UUPS(t *testing.T) {
+	testAccProxy(t, proxyKindUUPS)
+}
+
+func TestAccProxy_Transparent(t *testing.T) {
+	testAccProxy(t, proxyKindTransparent)
+}
@@ -4,15 +4,17 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/umbracle/ethgo"
 	"github.com/umbracle/ethgo/abi"
 )
 
 func TransactionResource() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Description: "Send a transaction.",
 		Schema: map[string]*schema.Schema{
 			"to": {
@@ -85,12 +87,6 @@ func TransactionResource() *schema.Resource {
 				ForceNew:    true,
 				Description: "The value of the transaction. This is the amount of wei transferred from the sender to the receiver. ",
 			},
-			"signer": {
-				Type:        schema.TypeString,
-				ForceNew:    true,
-				Required:    true,
-				Description: "The signer of the transaction. This is the private key of the wallet. ",
-			},
 			"hash": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -106,22 +102,400 @@ func TransactionResource() *schema.Resource {
 				Computed:    true,
 				Description: "The amount of gas used to execute the transaction.",
 			},
+			"block_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The hash of the block that includes the transaction.",
+			},
 		},
 		CreateContext: resourceTransactionCreate,
 		ReadContext:   resourceTransactionRead,
+		UpdateContext: resourceTransactionUpdate,
 		DeleteContext: resourceTransactionDelete,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return simulateTransactionDiff(d, meta.(*client), decodeTransaction)
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultCreateTimeout),
+		},
+	}
+	for k, v := range transactionSignerSchema() {
+		resource.Schema[k] = v
+	}
+	for k, v := range transactionFeeSchema() {
+		resource.Schema[k] = v
+	}
+	for k, v := range transactionWaitSchema() {
+		resource.Schema[k] = v
 	}
+	for k, v := range transactionReplacementSchema() {
+		resource.Schema[k] = v
+	}
+	for k, v := range transactionSimulationSchema() {
+		resource.Schema[k] = v
+	}
+	resource.SchemaVersion = 1
+	resource.StateUpgraders = transactionStateUpgraders(resource)
+	return resource
 }
 
-func resourceTransactionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	signer, err := hex.DecodeString(d.Get("signer").(string))
+// defaultCreateTimeout is the default time to wait for a
+// sent transaction to be included in a block.
+const defaultCreateTimeout = 5 * time.Minute
+
+var transactionTypes = map[string]ethgo.TransactionType{
+	"legacy":      ethgo.TransactionLegacy,
+	"access_list": ethgo.TransactionAccessList,
+	"dynamic_fee": ethgo.TransactionDynamicFee,
+}
+
+// transactionFeeSchema returns the attributes that select the type
+// of the transaction sent by a resource and how it is priced.
+func transactionFeeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringInSlice([]string{"legacy", "access_list", "dynamic_fee"}, false),
+			Description:  "The type of the transaction. It is either 'dynamic_fee' (EIP-1559), 'access_list' (EIP-2930) or 'legacy'. The last two are priced with the gas price for chains without London support. Defaults to 'dynamic_fee'.",
+		},
+		"max_fee_per_gas": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "The maximum fee per gas of a dynamic fee transaction. Defaults to twice the base fee of the latest block plus the priority fee.",
+		},
+		"max_priority_fee_per_gas": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "The maximum priority fee per gas of a dynamic fee transaction. Defaults to the value suggested by the node.",
+		},
+		"access_list": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "The addresses and storage keys accessed by a dynamic fee or access list transaction (EIP-2930).",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"address": {
+						Type:        schema.TypeString,
+						Required:    true,
+						ForceNew:    true,
+						Description: "The address accessed by the transaction.",
+					},
+					"storage_keys": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						ForceNew:    true,
+						Description: "The storage keys of the address accessed by the transaction.",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"auto_access_list": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"access_list"},
+			Description:   "Whether to generate the access list of the transaction with the node. It is only used if it lowers the gas of the transaction.",
+		},
+	}
+}
+
+// transactionStateUpgraders upgrades the state of the resources that send
+// transactions from the versions before the fee attributes (version 0).
+func transactionStateUpgraders(resource *schema.Resource) []schema.StateUpgrader {
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resource.CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeTransactionStateV0,
+		},
+	}
+}
+
+// upgradeTransactionStateV0 stores an empty access list for the transactions
+// sent before the access lists. Otherwise, the computed attribute is unknown
+// in the next plan and it forces the replacement of the resource. Likewise,
+// the hash of the transaction is stored as the only one sent.
+func upgradeTransactionStateV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		rawState = map[string]interface{}{}
+	}
+	if rawState["access_list"] == nil {
+		rawState["access_list"] = []interface{}{}
+	}
+	if rawState["hashes"] == nil {
+		hashes := []interface{}{}
+		if hash, ok := rawState["hash"].(string); ok && hash != "" {
+			hashes = append(hashes, hash)
+		}
+		rawState["hashes"] = hashes
+	}
+	return rawState, nil
+}
+
+// transactionWaitSchema returns the attributes that control when a sent
+// transaction is considered final. They only apply at creation time and
+// can be updated in place.
+func transactionWaitSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"confirmations": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The number of blocks on top of the one that includes the transaction to wait for. Defaults to the provider confirmations.",
+		},
+	}
+}
+
+// transactionReplacementSchema returns the attributes that replace a sent
+// transaction with higher fees if it is not included in a block.
+func transactionReplacementSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"fee_bump": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Replaces the transaction with the same one with higher fees if it is not included in a block after some time.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"interval": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      60,
+						ValidateFunc: validation.IntAtLeast(1),
+						Description:  "The number of seconds to wait for the transaction before it is replaced. Defaults to 60.",
+					},
+					"percentage": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      minReplacementPercentage,
+						ValidateFunc: validation.IntAtLeast(minReplacementPercentage),
+						Description:  "The percentage by which the fees are increased on every replacement. It must be at least 10 to be accepted by the nodes. Defaults to 10.",
+					},
+					"fee_cap": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The maximum gas price (legacy and access list) or max fee per gas (dynamic fee) of the replacements.",
+					},
+				},
+			},
+		},
+		"hashes": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The hashes of all the transactions sent, the original one and its replacements.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+// transactionSimulationSchema returns the attributes of the simulation
+// of the transaction against the latest block at plan time.
+func transactionSimulationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"simulate": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to simulate the transaction at plan time to detect reverts and estimate its gas and fee. The simulation runs against the latest block, without the effects of the other resources of the plan, and it is skipped if any input is not known until apply. Defaults to false.",
+		},
+		"estimated_gas": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The gas estimated for the transaction at plan time.",
+		},
+		"estimated_fee": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The fee in wei estimated for the transaction at plan time.",
+		},
+	}
+}
+
+// resourceGetter reads the attributes of a resource either at plan
+// time (schema.ResourceDiff) or at apply time (schema.ResourceData).
+type resourceGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+// simulateTransactionDiff runs the transaction of a new resource against the
+// latest block at plan time. A revert fails the plan and the estimated gas
+// and fee are set as computed attributes.
+func simulateTransactionDiff(d *schema.ResourceDiff, client *client, decode func(d resourceGetter, client *client) (*transaction, error)) error {
+	if d.Id() != "" || !d.Get("simulate").(bool) {
+		return nil
+	}
+	if !d.GetRawConfig().IsWhollyKnown() {
+		// the transaction depends on values that are only known after apply
+		return nil
+	}
+
+	txn, err := decode(d, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return err
+	}
+	gas, fee, err := client.simulateTransaction(txn)
+	if err != nil {
+		return err
+	}
+	if err := d.SetNew("estimated_gas", int(gas)); err != nil {
+		return err
+	}
+	return d.SetNew("estimated_fee", fee.String())
+}
+
+// decodeTransactionWait sets the number of confirmations of the transaction,
+// either from the resource or from the provider defaults.
+func decodeTransactionWait(d *schema.ResourceData, client *client, txn *transaction) {
+	txn.Confirmations = client.confirmations
+	if !d.GetRawConfig().GetAttr("confirmations").IsNull() {
+		txn.Confirmations = uint64(d.Get("confirmations").(int))
+	}
+}
+
+// decodeTransactionFees reads the type and the fee attributes of the
+// resource into the transaction.
+func decodeTransactionFees(d resourceGetter, txn *transaction) error {
+	typ := d.Get("type").(string)
+	if typ == "" {
+		// no default in the schema since it would replace the
+		// resources created before the transaction types
+		typ = "dynamic_fee"
+	}
+	txnType, ok := transactionTypes[typ]
+	if !ok {
+		return fmt.Errorf("transaction type '%s' not found", typ)
+	}
+	txn.Type = txnType
+
+	var err error
+	if val, ok := d.GetOk("max_fee_per_gas"); ok {
+		if txn.Type != ethgo.TransactionDynamicFee {
+			return fmt.Errorf("max_fee_per_gas is only valid for dynamic fee transactions")
+		}
+		if txn.MaxFeePerGas, err = parseEtherValue(val.(string)); err != nil {
+			return fmt.Errorf("failed to parse max fee per gas '%s': %v", val.(string), err)
+		}
+	}
+	if val, ok := d.GetOk("max_priority_fee_per_gas"); ok {
+		if txn.Type != ethgo.TransactionDynamicFee {
+			return fmt.Errorf("max_priority_fee_per_gas is only valid for dynamic fee transactions")
+		}
+		if txn.MaxPriorityFeePerGas, err = parseEtherValue(val.(string)); err != nil {
+			return fmt.Errorf("failed to parse max priority fee per gas '%s': %v", val.(string), err)
+		}
+	}
+
+	txn.AutoAccessList = d.Get("auto_access_list").(bool)
+	if val, ok := d.GetOk("access_list"); ok {
+		for _, raw := range val.([]interface{}) {
+			obj := raw.(map[string]interface{})
+
+			entry := ethgo.AccessEntry{
+				Address: ethgo.HexToAddress(obj["address"].(string)),
+				Storage: []ethgo.Hash{},
+			}
+			for _, key := range obj["storage_keys"].([]interface{}) {
+				entry.Storage = append(entry.Storage, ethgo.HexToHash(key.(string)))
+			}
+			txn.AccessList = append(txn.AccessList, entry)
+		}
+	}
+	if (txn.AutoAccessList || len(txn.AccessList) != 0) && txn.Type == ethgo.TransactionLegacy {
+		return fmt.Errorf("access lists are not valid for legacy transactions")
+	}
+	return nil
+}
+
+// decodeTransactionReplacement reads the fee bump settings of the resource.
+func decodeTransactionReplacement(d *schema.ResourceData, txn *transaction) error {
+	val, ok := d.GetOk("fee_bump")
+	if !ok {
+		return nil
+	}
+	obj := val.([]interface{})[0].(map[string]interface{})
+
+	txn.FeeBump = &feeBump{
+		Interval:   time.Duration(obj["interval"].(int)) * time.Second,
+		Percentage: uint64(obj["percentage"].(int)),
+	}
+	if feeCap := obj["fee_cap"].(string); feeCap != "" {
+		var err error
+		if txn.FeeBump.FeeCap, err = parseEtherValue(feeCap); err != nil {
+			return fmt.Errorf("failed to parse fee cap '%s': %v", feeCap, err)
+		}
+	}
+	return nil
+}
+
+// setTransactionHashes stores the hashes of all the transactions sent.
+func setTransactionHashes(d *schema.ResourceData, txn *transaction) {
+	hashes := make([]string, 0, len(txn.Hashes))
+	for _, hash := range txn.Hashes {
+		hashes = append(hashes, hash.String())
+	}
+	d.Set("hashes", hashes)
+}
+
+// setTransactionFees stores the fees filled in by the node for the
+// attributes that were not set by the user and the access list.
+func setTransactionFees(d *schema.ResourceData, txn *transaction) {
+	if txn.Type == ethgo.TransactionDynamicFee {
+		if _, ok := d.GetOk("max_fee_per_gas"); !ok {
+			d.Set("max_fee_per_gas", txn.MaxFeePerGas.String())
+		}
+		if _, ok := d.GetOk("max_priority_fee_per_gas"); !ok {
+			d.Set("max_priority_fee_per_gas", txn.MaxPriorityFeePerGas.String())
+		}
+	}
+
+	if _, ok := d.GetOk("access_list"); ok {
+		return
+	}
+	// the access list is always stored, even if empty (i.e. legacy transactions),
+	// otherwise the computed attribute is unknown in every plan and it forces
+	// the replacement of the resource
+	accessList := make([]interface{}, 0, len(txn.AccessList))
+	for _, entry := range txn.AccessList {
+		keys := make([]string, 0, len(entry.Storage))
+		for _, key := range entry.Storage {
+			keys = append(keys, key.String())
+		}
+		accessList = append(accessList, map[string]interface{}{
+			"address":      entry.Address.String(),
+			"storage_keys": keys,
+		})
+	}
+	d.Set("access_list", accessList)
+}
+
+// decodeTransaction builds the transaction of the resource from its
+// attributes, both at plan time (to simulate it) and at apply time.
+func decodeTransaction(d resourceGetter, client *client) (*transaction, error) {
+	signer, err := decodeSigner(d, client)
+	if err != nil {
+		return nil, err
 	}
 
 	txn := &transaction{
 		Signer: signer,
 	}
+	if err := decodeTransactionFees(d, txn); err != nil {
+		return nil, err
+	}
 
 	if val, ok := d.GetOk("to"); ok {
 		addr := ethgo.HexToAddress(val.(string))
@@ -130,20 +504,20 @@ func resourceTransactionCreate(ctx context.Context, d *schema.ResourceData, meta
 	if val, ok := d.GetOk("value"); ok {
 		txn.Value, err = parseEtherValue(val.(string))
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to parse transfer value '%s': %v", val.(string), err))
+			return nil, fmt.Errorf("failed to parse transfer value '%s': %v", val.(string), err)
 		}
 	}
 	if val, ok := d.GetOk("raw_input"); ok {
 		buf, err := hex.DecodeString(val.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, err
 		}
 		txn.Input = buf
 	}
 	if val, ok := d.GetOk("gas_limit"); ok {
 		gasLimit := val.(int)
 		if gasLimit < 0 {
-			return diag.FromErr(fmt.Errorf("gas limit cannot be less than 0 but %d found", gasLimit))
+			return nil, fmt.Errorf("gas limit cannot be less than 0 but %d found", gasLimit)
 		}
 		txn.GasLimit = uint64(gasLimit)
 	}
@@ -153,17 +527,18 @@ func resourceTransactionCreate(ctx context.Context, d *schema.ResourceData, meta
 	if val, ok := d.GetOk("artifact"); ok {
 		artifact, err := resolveContract(val.(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, err
 		}
 		methodName := d.Get("method").(string)
+		txn.Abi = artifact.Abi
 		method, ok = artifact.Abi.Methods[methodName]
 		if !ok {
-			return diag.FromErr(fmt.Errorf("method '%s' not found", methodName))
+			return nil, fmt.Errorf("method '%s' not found", methodName)
 		}
 	}
 	if val, ok := d.GetOk("function"); ok {
 		if method, err = abi.NewMethod(val.(string)); err != nil {
-			return diag.FromErr(fmt.Errorf("failed to parse function '%s': %v", val.(string), err))
+			return nil, fmt.Errorf("failed to parse function '%s': %v", val.(string), err)
 		}
 	}
 
@@ -172,7 +547,7 @@ func resourceTransactionCreate(ctx context.Context, d *schema.ResourceData, meta
 		if rawInputs, ok := d.GetOk("input"); ok {
 			inputs, err = decodeInputs(rawInputs)
 			if err != nil {
-				return diag.FromErr(fmt.Errorf("failed to decode inputs: %v", err))
+				return nil, fmt.Errorf("failed to decode inputs: %v", err)
 			}
 		} else {
 			inputs = []interface{}{}
@@ -180,13 +555,27 @@ func resourceTransactionCreate(ctx context.Context, d *schema.ResourceData, meta
 
 		buf, err := method.Encode(inputs)
 		if err != nil {
-			return diag.FromErr(fmt.Errorf("failed to abi encode: %v", err))
+			return nil, fmt.Errorf("failed to abi encode: %v", err)
 		}
 		txn.Input = buf
 	}
+	return txn, nil
+}
 
+func resourceTransactionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client)
-	hash, receipt, err := client.sendTransaction(txn)
+
+	txn, err := decodeTransaction(d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	decodeTransactionWait(d, client, txn)
+	if err := decodeTransactionReplacement(d, txn); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash, receipt, err := client.sendTransaction(ctx, txn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -195,6 +584,10 @@ func resourceTransactionCreate(ctx context.Context, d *schema.ResourceData, meta
 	d.Set("hash", hash.String())
 	d.Set("gas_used", receipt.GasUsed)
 	d.Set("block_num", int(receipt.BlockNumber))
+	d.Set("block_hash", receipt.BlockHash.String())
+	setTransactionFees(d, txn)
+	setTransactionHashes(d, txn)
+	d.Set("signer_address", txn.Signer.Address().String())
 
 	return nil
 }
@@ -203,19 +596,34 @@ func resourceTransactionRead(ctx context.Context, d *schema.ResourceData, meta i
 	client := meta.(*client)
 
 	hash := d.Id()
-	receipt, err := client.Http().GetTransactionReceipt(ethgo.HexToHash(hash))
+	receipt, included, err := client.includedReceipt(ethgo.HexToHash(hash), uint64(d.Get("block_num").(int)), ethgo.HexToHash(d.Get("block_hash").(string)))
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if !included {
+		// the transaction is not part of the canonical chain anymore,
+		// remove it from the state so that it is sent again.
+		d.SetId("")
+		return nil
+	}
+	if receipt == nil {
+		// the transaction is still in the same block
+		return nil
+	}
 
-	d.SetId(hash)
-	d.Set("hash", hash)
 	d.Set("gas_used", receipt.GasUsed)
 	d.Set("block_num", int(receipt.BlockNumber))
+	d.Set("block_hash", receipt.BlockHash.String())
 
 	return nil
 }
 
+func resourceTransactionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only the attributes that do not modify the sent transaction can be
+	// updated and they are stored in the state by Terraform.
+	return resourceTransactionRead(ctx, d, meta)
+}
+
 func resourceTransactionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
@@ -1,11 +1,44 @@
 package ethereum
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc/codec"
+	"github.com/umbracle/ethgo/wallet"
 )
 
+// testDiff returns the diff of the next plan of a resource with the state and the config.
+func testDiff(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+	t.Helper()
+
+	diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), (*client)(nil))
+	require.NoError(t, err)
+	if diff == nil {
+		diff = &terraform.InstanceDiff{Attributes: map[string]*terraform.ResourceAttrDiff{}}
+	}
+	return diff
+}
+
+// testPlanDiff returns the diff of the next plan of a resource with the
+// state and the config. The resource must not be replaced.
+func testPlanDiff(t *testing.T, r *schema.Resource, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+	t.Helper()
+
+	diff := testDiff(t, r, state, config)
+	require.False(t, diff.RequiresNew(), "unexpected replacement: %v", diff.Attributes)
+	return diff
+}
+
 func checkTransactionDeployed() resource.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(
 		resource.TestCheckResourceAttrSet(
@@ -154,3 +187,468 @@ func TestAccTransaction_method_fromSignature(t *testing.T) {
 		},
 	})
 }
+
+func TestAccTransaction_Legacy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_eoa" "account" {
+					mnemonic = "test test test test test test test test test test test junk"
+				}
+
+				resource "ethereum_transaction" "update" {
+					signer = data.ethereum_eoa.account.signer
+					to = "0x74B73aC4158B64004F8379966052b215E2A5fc77"
+					value = 100
+					type = "legacy"
+				}
+				`,
+				Check: checkTransactionDeployed(),
+			},
+		},
+	})
+}
+
+func TestSetTransactionFees_Legacy(t *testing.T) {
+	r := TransactionResource()
+	config := map[string]interface{}{
+		"signer": "0x1",
+		"to":     "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+		"type":   "legacy",
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("0x1")
+	setTransactionFees(d, &transaction{Type: ethgo.TransactionLegacy})
+
+	// the empty access list is stored and the transaction is not sent again
+	testPlanDiff(t, r, d.State(), config)
+}
+
+// testBaselineTransactionState returns the state of a transaction sent by
+// the provider versions before the fee attributes (schema version 0).
+func testBaselineTransactionState() (*terraform.InstanceState, map[string]interface{}) {
+	config := map[string]interface{}{
+		"signer": "0x1",
+		"to":     "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+		"value":  "100",
+	}
+	state := &terraform.InstanceState{
+		ID: "0x2",
+		Attributes: map[string]string{
+			"id":         "0x2",
+			"signer":     "0x1",
+			"to":         "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+			"value":      "100",
+			"hash":       "0x2",
+			"gas_used":   "21000",
+			"block_num":  "1",
+			"block_hash": "0x3",
+		},
+	}
+	return state, config
+}
+
+func TestTransactionRead_PrunedReceipt(t *testing.T) {
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+			return nil, nil
+		},
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			return testBlock(1, ethgo.HexToHash("0x3")), nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	state, _ := testBaselineTransactionState()
+	d := TransactionResource().Data(state)
+
+	// the node pruned the receipt of the transaction but its block is
+	// still canonical and the transaction is not sent again
+	require.False(t, resourceTransactionRead(context.Background(), d, clt).HasError())
+	require.Equal(t, "0x2", d.Id())
+}
+
+func TestUpgradeTransactionStateV0(t *testing.T) {
+	rawState, err := upgradeTransactionStateV0(context.Background(), map[string]interface{}{"id": "0x2", "hash": "0x2"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{}, rawState["access_list"])
+	require.Equal(t, []interface{}{"0x2"}, rawState["hashes"])
+
+	// the upgraded state has an empty access list
+	state, config := testBaselineTransactionState()
+	state.Attributes["access_list.#"] = "0"
+	state.Attributes["hashes.#"] = "1"
+	state.Attributes["hashes.0"] = "0x2"
+
+	diff := testDiff(t, TransactionResource(), state, config)
+	require.Nil(t, diff.Attributes["access_list.#"])
+	require.Nil(t, diff.Attributes["hashes.#"])
+	require.Nil(t, diff.Attributes["auto_access_list"])
+}
+
+func TestTransactionType_Baseline(t *testing.T) {
+	state, config := testBaselineTransactionState()
+
+	// the resources created before the transaction types are not replaced
+	diff := testDiff(t, TransactionResource(), state, config)
+	require.Nil(t, diff.Attributes["type"])
+
+	txn := &transaction{}
+	require.NoError(t, decodeTransactionFees(schema.TestResourceDataRaw(t, TransactionResource().Schema, config), txn))
+	require.Equal(t, ethgo.TransactionDynamicFee, txn.Type)
+}
+
+func TestTransactionType_AccessList(t *testing.T) {
+	config := map[string]interface{}{
+		"signer": "0x1",
+		"to":     "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+		"type":   "access_list",
+		"access_list": []interface{}{
+			map[string]interface{}{
+				"address":      "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+				"storage_keys": []interface{}{"0x1"},
+			},
+		},
+	}
+
+	txn := &transaction{}
+	require.NoError(t, decodeTransactionFees(schema.TestResourceDataRaw(t, TransactionResource().Schema, config), txn))
+	require.Equal(t, ethgo.TransactionAccessList, txn.Type)
+	require.Len(t, txn.AccessList, 1)
+
+	// legacy transactions do not have an access list
+	config["type"] = "legacy"
+	err := decodeTransactionFees(schema.TestResourceDataRaw(t, TransactionResource().Schema, config), &transaction{})
+	require.Error(t, err)
+
+	// the fees are only valid for dynamic fee transactions
+	config["type"] = "access_list"
+	config["max_fee_per_gas"] = "10"
+	err = decodeTransactionFees(schema.TestResourceDataRaw(t, TransactionResource().Schema, config), &transaction{})
+	require.Error(t, err)
+}
+
+func TestTransactionImpersonate_Baseline(t *testing.T) {
+	state, config := testBaselineTransactionState()
+
+	// the resources created before the node signers are not replaced
+	diff := testDiff(t, TransactionResource(), state, config)
+	require.Nil(t, diff.Attributes["impersonate"])
+}
+
+func TestAccTransaction_DynamicFee(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_eoa" "account" {
+					mnemonic = "test test test test test test test test test test test junk"
+				}
+
+				resource "ethereum_transaction" "update" {
+					signer = data.ethereum_eoa.account.signer
+					to = "0x74B73aC4158B64004F8379966052b215E2A5fc77"
+					value = 100
+					max_fee_per_gas = "100 gwei"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					checkTransactionDeployed(),
+					resource.TestCheckResourceAttr(
+						"ethereum_transaction.update", "max_fee_per_gas", "100 gwei"),
+					resource.TestCheckResourceAttrSet(
+						"ethereum_transaction.update", "max_priority_fee_per_gas"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTransaction_Revert(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_eoa" "account" {
+					mnemonic = "test test test test test test test test test test test junk"
+				}
+
+				resource "ethereum_contract_deployment" "deploy" {
+					signer = data.ethereum_eoa.account.signer
+					artifact = "../testcases/out:Reverts"
+				}
+
+				resource "ethereum_transaction" "update" {
+					signer = data.ethereum_eoa.account.signer
+					to = resource.ethereum_contract_deployment.deploy.contract_address
+
+					artifact = "../testcases/out:Reverts"
+					method = "withCustomError"
+
+					// skip the gas estimation so that the transaction is included
+					gas_limit = 100000
+				}
+				`,
+				ExpectError: regexp.MustCompile("reverted: Unauthorized"),
+			},
+		},
+	})
+}
+
+func TestAccTransaction_RevertEstimation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_eoa" "account" {
+					mnemonic = "test test test test test test test test test test test junk"
+				}
+
+				resource "ethereum_contract_deployment" "deploy" {
+					signer = data.ethereum_eoa.account.signer
+					artifact = "../testcases/out:Reverts"
+				}
+
+				resource "ethereum_transaction" "update" {
+					signer = data.ethereum_eoa.account.signer
+					to = resource.ethereum_contract_deployment.deploy.contract_address
+					function = "withReason()"
+				}
+				`,
+				ExpectError: regexp.MustCompile("execution reverted: not allowed"),
+			},
+		},
+	})
+}
+
+func TestAccTransaction_Confirmations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_eoa" "account" {
+					mnemonic = "test test test test test test test test test test test junk"
+				}
+
+				resource "ethereum_transaction" "update" {
+					signer = data.ethereum_eoa.account.signer
+					to = "0x74B73aC4158B64004F8379966052b215E2A5fc77"
+					value = 100
+					confirmations = 2
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					checkTransactionDeployed(),
+					resource.TestCheckResourceAttrSet(
+						"ethereum_transaction.update", "block_hash"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTransaction_Simulation(t *testing.T) {
+	deploy := `
+	data "ethereum_eoa" "account" {
+		mnemonic = "test test test test test test test test test test test junk"
+	}
+
+	resource "ethereum_contract_deployment" "deploy" {
+		signer = data.ethereum_eoa.account.signer
+		artifact = "../testcases/out:Reverts"
+		simulate = true
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: deploy,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"ethereum_contract_deployment.deploy", "estimated_gas"),
+					resource.TestCheckResourceAttrSet(
+						"ethereum_contract_deployment.deploy", "estimated_fee"),
+				),
+			},
+			{
+				// the address of the contract is known at plan time
+				// and the revert is detected before applying
+				Config: deploy + `
+				resource "ethereum_transaction" "update" {
+					signer = data.ethereum_eoa.account.signer
+					to = resource.ethereum_contract_deployment.deploy.contract_address
+					function = "withReason()"
+					simulate = true
+				}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("simulation failed: execution reverted: not allowed"),
+			},
+		},
+	})
+}
+
+func TestTransactionSimulation_OptIn(t *testing.T) {
+	var calls int32
+	srv := newTestRPCServer(t, map[string]testRPCHandler{
+		"eth_call": func(params []json.RawMessage) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, &codec.ErrorObject{Code: 3, Message: "execution reverted"}
+		},
+		"eth_estimateGas": func(params []json.RawMessage) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return "0x5208", nil
+		},
+		"eth_maxPriorityFeePerGas": func(params []json.RawMessage) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return "0x2", nil
+		},
+		"eth_getBlockByNumber": func(params []json.RawMessage) (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return testBlock(1, ethgo.Hash{0x1}), nil
+		},
+	})
+
+	clt, err := newClient(srv.URL)
+	require.NoError(t, err)
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+	priv, err := key.MarshallPrivateKey()
+	require.NoError(t, err)
+
+	config := map[string]interface{}{
+		"signer": hex.EncodeToString(priv),
+		"to":     "0x74B73aC4158B64004F8379966052b215E2A5fc77",
+	}
+
+	// the transactions may depend on the effects of other resources
+	// of the same plan and they are only simulated on request
+	_, err = TransactionResource().SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(config), clt)
+	require.NoError(t, err)
+	require.Zero(t, atomic.LoadInt32(&calls))
+
+	// the revert of the simulated transaction fails the plan
+	config["simulate"] = true
+	_, err = TransactionResource().SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(config), clt)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "simulation failed")
+	require.Contains(t, err.Error(), "execution reverted")
+	require.NotZero(t, atomic.LoadInt32(&calls))
+}
+
+func TestAccTransaction_AccessList(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "ethereum_eoa" "account" {
+					mnemonic = "test test test test test test test test test test test junk"
+				}
+
+				resource "ethereum_eoa" "target" {}
+
+				resource "ethereum_transaction" "update" {
+					signer = data.ethereum_eoa.account.signer
+					to     = resource.ethereum_eoa.target.address
+					value  = "1 gwei"
+
+					access_list {
+						address = resource.ethereum_eoa.target.address
+						storage_keys = [
+							"0x0000000000000000000000000000000000000000000000000000000000000001",
+						]
+					}
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					checkTransactionDeployed(),
+					resource.TestCheckResourceAttr(
+						"ethereum_transaction.update", "access_list.#", "1"),
+					resource.TestCheckResourceAttr(
+						"ethereum_transaction.update", "access_list.0.storage_keys.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTransaction_SignerName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "ethereum" {
+					signer {
+						name     = "deployer"
+						mnemonic = "test test test test test test test test test test test junk"
+						index    = 1
+					}
+				}
+
+				resource "ethereum_eoa" "target" {}
+
+				resource "ethereum_transaction" "update" {
+					signer_name = "deployer"
+					to          = resource.ethereum_eoa.target.address
+					value       = "1 gwei"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					checkTransactionDeployed(),
+					resource.TestCheckNoResourceAttr(
+						"ethereum_transaction.update", "signer"),
+					resource.TestCheckResourceAttr(
+						"ethereum_transaction.update", "signer_address", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTransaction_From(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "ethereum_eoa" "target" {}
+
+				resource "ethereum_transaction" "update" {
+					from        = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+					impersonate = true
+					to          = resource.ethereum_eoa.target.address
+					value       = "1 gwei"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					checkTransactionDeployed(),
+					resource.TestCheckResourceAttr(
+						"ethereum_transaction.update", "signer_address", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+				),
+			},
+		},
+	})
+}
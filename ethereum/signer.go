@@ -0,0 +1,397 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/keystore"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+// signerKeys are the attributes that select the signer of a resource.
+var signerKeys = []string{"signer", "keystore_path", "signer_name", "from"}
+
+// transactionSignerSchema returns the attributes that select the
+// account that signs the transaction sent by a resource.
+func transactionSignerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"signer": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: signerKeys,
+			Description:  "The signer of the transaction. This is the private key of the wallet.",
+		},
+		"keystore_path": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: signerKeys,
+			Description:  "The path to an encrypted keystore (V3) file with the key of the signer. Alternative to signer.",
+		},
+		"signer_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: signerKeys,
+			Description:  "The name of a signer configured in the provider. Alternative to signer.",
+		},
+		"from": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: signerKeys,
+			Description:  "The address of an account of the node. The transaction is sent unsigned with eth_sendTransaction and signed by the node (i.e. the unlocked accounts of a development node). Alternative to signer.",
+		},
+		"impersonate": {
+			Type:         schema.TypeBool,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: []string{"from"},
+			Description:  "Impersonate the from account (anvil_impersonateAccount) before sending the transaction. It allows to send transactions from any address in a (forked) development node without its key.",
+		},
+		"keystore_password_env": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			RequiredWith:  []string{"keystore_path"},
+			ConflictsWith: []string{"keystore_password_file"},
+			Description:   "The name of the environment variable with the password of the keystore.",
+		},
+		"keystore_password_file": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			RequiredWith:  []string{"keystore_path"},
+			ConflictsWith: []string{"keystore_password_env"},
+			Description:   "The path to the file with the password of the keystore.",
+		},
+		"signer_address": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The address of the signer of the transaction.",
+		},
+	}
+}
+
+// decodeSigner returns the signer of the resource, either from the private key
+// of the signer attribute, the signers of the provider, the keystore or the node.
+func decodeSigner(d resourceGetter, client *client) (transactionSigner, error) {
+	if val, ok := d.GetOk("from"); ok {
+		signer := &nodeSigner{
+			address:     ethgo.HexToAddress(val.(string)),
+			impersonate: d.Get("impersonate").(bool),
+		}
+		return signer, nil
+	}
+	if val, ok := d.GetOk("signer"); ok {
+		priv, err := hex.DecodeString(val.(string))
+		if err != nil {
+			return nil, err
+		}
+		return newKeySigner(priv)
+	}
+	if val, ok := d.GetOk("signer_name"); ok {
+		signer, ok := client.signers[val.(string)]
+		if !ok {
+			return nil, fmt.Errorf("signer '%s' is not configured in the provider", val.(string))
+		}
+		return signer, nil
+	}
+
+	path, ok := d.GetOk("keystore_path")
+	if !ok {
+		return nil, fmt.Errorf("signer not found")
+	}
+	password, err := readPassword(d.Get("keystore_password_env").(string), d.Get("keystore_password_file").(string))
+	if err != nil {
+		return nil, err
+	}
+	priv, err := decryptKeystore(path.(string), password)
+	if err != nil {
+		return nil, err
+	}
+	return newKeySigner(priv)
+}
+
+// decryptKeystore returns the private key of a V3 keystore file.
+func decryptKeystore(path, password string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore '%s': %v", path, err)
+	}
+	priv, err := keystore.DecryptV3(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore '%s': %v", path, err)
+	}
+	return priv, nil
+}
+
+// readPassword returns a password either from an environment variable or from
+// a file. The trailing new line of the file is not part of the password.
+func readPassword(env, file string) (string, error) {
+	if env != "" {
+		password, ok := os.LookupEnv(env)
+		if !ok {
+			return "", fmt.Errorf("environment variable '%s' with the password is not set", env)
+		}
+		return password, nil
+	}
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password file '%s': %v", file, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	return "", nil
+}
+
+// providerSignerSchema returns the attributes of the named
+// signers configured in the provider.
+func providerSignerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name used by the resources to refer to the signer.",
+		},
+		"private_key_env": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of the environment variable with the hex encoded private key.",
+		},
+		"private_key_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The path to the file with the hex encoded private key.",
+		},
+		"keystore_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The path to an encrypted keystore (V3) file.",
+		},
+		"keystore_password_env": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of the environment variable with the password of the keystore.",
+		},
+		"keystore_password_file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The path to the file with the password of the keystore.",
+		},
+		"mnemonic": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "The mnemonic of the wallet.",
+		},
+		"index": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     0,
+			Description: "The index of the account derived from the mnemonic. Defaults to 0.",
+		},
+		"remote_url": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The url of a remote signing service that holds the key of the signer.",
+		},
+		"remote_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      remoteSignerJSONRPC,
+			ValidateFunc: validation.StringInSlice([]string{remoteSignerJSONRPC, remoteSignerWeb3Signer}, false),
+			Description:  "The api of the remote signing service. It is either 'jsonrpc' (eth_signTransaction, i.e. Clef) or 'web3signer' (/api/v1/eth1/sign). Defaults to 'jsonrpc'.",
+		},
+		"command": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The executable and arguments of an external signer. It reads the unsigned transaction as json (as in eth_signTransaction) from stdin and writes the signed raw transaction to stdout.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"address": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The address of the account of the remote signing service or the external signer.",
+		},
+	}
+}
+
+// decodeProviderSigners returns the named
+// signers of the provider indexed by name.
+func decodeProviderSigners(raw []interface{}) (map[string]transactionSigner, error) {
+	signers := map[string]transactionSigner{}
+	for _, item := range raw {
+		obj := item.(map[string]interface{})
+
+		name := obj["name"].(string)
+		if _, ok := signers[name]; ok {
+			return nil, fmt.Errorf("signer '%s' is configured more than once", name)
+		}
+		signer, err := decodeProviderSigner(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load signer '%s': %v", name, err)
+		}
+		signers[name] = signer
+	}
+	return signers, nil
+}
+
+func decodeProviderSigner(obj map[string]interface{}) (transactionSigner, error) {
+	var sources []string
+	for _, k := range []string{"private_key_env", "private_key_file", "keystore_path", "mnemonic", "remote_url"} {
+		if obj[k].(string) != "" {
+			sources = append(sources, k)
+		}
+	}
+	var command []string
+	for _, arg := range obj["command"].([]interface{}) {
+		command = append(command, arg.(string))
+	}
+	if len(command) != 0 {
+		sources = append(sources, "command")
+	}
+	if len(sources) != 1 {
+		return nil, fmt.Errorf("exactly one of private_key_env, private_key_file, keystore_path, mnemonic, remote_url or command must be set")
+	}
+
+	switch sources[0] {
+	case "remote_url", "command":
+		address := obj["address"].(string)
+		if address == "" {
+			return nil, fmt.Errorf("the address is required for remote and command signers")
+		}
+		if sources[0] == "command" {
+			return newCommandSigner(command, ethgo.HexToAddress(address)), nil
+		}
+		return newRemoteSigner(obj["remote_url"].(string), obj["remote_type"].(string), ethgo.HexToAddress(address)), nil
+	}
+
+	priv, err := decodeProviderKey(obj, sources[0])
+	if err != nil {
+		return nil, err
+	}
+	return newKeySigner(priv)
+}
+
+// decodeProviderKey returns the private key of a named signer from its source.
+func decodeProviderKey(obj map[string]interface{}, source string) ([]byte, error) {
+	switch source {
+	case "private_key_env":
+		env := obj["private_key_env"].(string)
+		val, ok := os.LookupEnv(env)
+		if !ok {
+			return nil, fmt.Errorf("environment variable '%s' with the private key is not set", env)
+		}
+		return decodePrivateKey(val)
+
+	case "private_key_file":
+		data, err := os.ReadFile(obj["private_key_file"].(string))
+		if err != nil {
+			return nil, err
+		}
+		return decodePrivateKey(string(data))
+
+	case "keystore_path":
+		password, err := readPassword(obj["keystore_password_env"].(string), obj["keystore_password_file"].(string))
+		if err != nil {
+			return nil, err
+		}
+		return decryptKeystore(obj["keystore_path"].(string), password)
+
+	default:
+		key, err := deriveKey(obj["mnemonic"].(string), "", accountDerivationPath(defaultDerivationPath, uint32(obj["index"].(int))))
+		if err != nil {
+			return nil, err
+		}
+		return key.MarshallPrivateKey()
+	}
+}
+
+// decodePrivateKey decodes a hex encoded private key with or without prefix.
+func decodePrivateKey(str string) ([]byte, error) {
+	str = strings.TrimPrefix(strings.TrimSpace(str), "0x")
+
+	priv, err := hex.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key: %v", err)
+	}
+	if _, err := wallet.NewWalletFromPrivKey(priv); err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+	return priv, nil
+}
+
+// defaultDerivationPath is the path of the parent key of the
+// accounts derived from a mnemonic (m/44'/60'/0'/0).
+var defaultDerivationPath = wallet.DefaultDerivationPath[:4]
+
+// parseDerivationPath parses a BIP-32 derivation path (i.e. m/44'/60'/0'/0).
+// The hardened indexes are marked with an apostrophe or an h.
+func parseDerivationPath(str string) (wallet.DerivationPath, error) {
+	parts := strings.Split(strings.TrimSpace(str), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("derivation path '%s' does not start with m", str)
+	}
+
+	path := wallet.DerivationPath{}
+	for _, part := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") {
+			part = part[:len(part)-1]
+			offset = hdkeychain.HardenedKeyStart
+		}
+		num, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index '%s' in derivation path '%s'", part, str)
+		}
+		path = append(path, uint32(num)+offset)
+	}
+	return path, nil
+}
+
+// decodeDerivationPath returns the parent path of the accounts
+// of the derivation_path attribute or the default one if not set.
+func decodeDerivationPath(d resourceGetter) (wallet.DerivationPath, error) {
+	val, ok := d.GetOk("derivation_path")
+	if !ok {
+		return defaultDerivationPath, nil
+	}
+	return parseDerivationPath(val.(string))
+}
+
+// accountDerivationPath returns the path of the account
+// with the index under the parent path (i.e. parent/index).
+func accountDerivationPath(parent wallet.DerivationPath, index uint32) wallet.DerivationPath {
+	path := make(wallet.DerivationPath, len(parent), len(parent)+1)
+	copy(path, parent)
+	return append(path, index)
+}
+
+// deriveKey derives the key of the path from the mnemonic and passphrase (BIP-39).
+func deriveKey(mnemonic, passphrase string, path wallet.DerivationPath) (*wallet.Key, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := path.Derive(master)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.NewKey(priv), nil
+}
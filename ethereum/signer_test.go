@@ -0,0 +1,132 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/keystore"
+	"github.com/umbracle/ethgo/wallet"
+)
+
+func TestDecodeSigner_Keystore(t *testing.T) {
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+	priv, err := key.MarshallPrivateKey()
+	require.NoError(t, err)
+
+	data, err := keystore.EncryptV3(priv, "password", 1<<4)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	keystorePath := filepath.Join(dir, "keystore.json")
+	passwordPath := filepath.Join(dir, "password")
+
+	require.NoError(t, os.WriteFile(keystorePath, data, 0600))
+	require.NoError(t, os.WriteFile(passwordPath, []byte("password\n"), 0600))
+
+	decode := func(raw map[string]interface{}) (transactionSigner, error) {
+		d := schema.TestResourceDataRaw(t, TransactionResource().Schema, raw)
+		return decodeSigner(d, &client{})
+	}
+
+	// password from a file
+	signer, err := decode(map[string]interface{}{
+		"keystore_path":          keystorePath,
+		"keystore_password_file": passwordPath,
+	})
+	require.NoError(t, err)
+	require.Equal(t, key.Address(), signer.Address())
+
+	// password from an environment variable
+	t.Setenv("TEST_KEYSTORE_PASSWORD", "password")
+
+	signer, err = decode(map[string]interface{}{
+		"keystore_path":         keystorePath,
+		"keystore_password_env": "TEST_KEYSTORE_PASSWORD",
+	})
+	require.NoError(t, err)
+	require.Equal(t, key.Address(), signer.Address())
+
+	// wrong password
+	t.Setenv("TEST_KEYSTORE_PASSWORD", "wrong")
+
+	_, err = decode(map[string]interface{}{
+		"keystore_path":         keystorePath,
+		"keystore_password_env": "TEST_KEYSTORE_PASSWORD",
+	})
+	require.Error(t, err)
+}
+
+func TestDecodeProviderSigners(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+
+	key, err := wallet.GenerateKey()
+	require.NoError(t, err)
+	priv, err := key.MarshallPrivateKey()
+	require.NoError(t, err)
+
+	privPath := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(privPath, []byte(hex.EncodeToString(priv)+"\n"), 0600))
+
+	t.Setenv("TEST_PRIVATE_KEY", "0x"+hex.EncodeToString(priv))
+
+	decode := func(signers ...map[string]interface{}) (map[string]transactionSigner, error) {
+		raw := []interface{}{}
+		for _, signer := range signers {
+			raw = append(raw, signer)
+		}
+		d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"signer": raw})
+		return decodeProviderSigners(d.Get("signer").([]interface{}))
+	}
+
+	signers, err := decode(
+		map[string]interface{}{"name": "env", "private_key_env": "TEST_PRIVATE_KEY"},
+		map[string]interface{}{"name": "file", "private_key_file": privPath},
+		map[string]interface{}{"name": "first", "mnemonic": mnemonic},
+		map[string]interface{}{"name": "second", "mnemonic": mnemonic, "index": 1},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, key.Address(), signers["env"].Address())
+	require.Equal(t, key.Address(), signers["file"].Address())
+
+	address := func(name string) string {
+		return signers[name].Address().String()
+	}
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", address("first"))
+	require.Equal(t, "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", address("second"))
+
+	// the names are unique
+	_, err = decode(
+		map[string]interface{}{"name": "a", "mnemonic": mnemonic},
+		map[string]interface{}{"name": "a", "mnemonic": mnemonic},
+	)
+	require.Error(t, err)
+
+	// only one key source per signer
+	_, err = decode(
+		map[string]interface{}{"name": "a", "mnemonic": mnemonic, "private_key_file": privPath},
+	)
+	require.Error(t, err)
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	path, err := parseDerivationPath("m/44'/60'/0'/0")
+	require.NoError(t, err)
+	require.Equal(t, defaultDerivationPath, path)
+
+	path, err = parseDerivationPath("m/44h/60h/1h/0/5")
+	require.NoError(t, err)
+	require.Equal(t, wallet.DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 1, 0, 5}, path)
+
+	require.Equal(t, wallet.DefaultDerivationPath, accountDerivationPath(defaultDerivationPath, 0))
+
+	for _, str := range []string{"", "44'/60'", "m/a", "m/-1", "m/2147483648"} {
+		_, err := parseDerivationPath(str)
+		require.Error(t, err, str)
+	}
+}
@@ -1,6 +1,8 @@
 package ethereum
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -18,12 +20,14 @@ type artifact struct {
 }
 
 type bytecode struct {
-	Object string `json:"object"`
+	Object         string         `json:"object"`
+	LinkReferences linkReferences `json:"linkReferences"`
 }
 
 type artifactHardhat struct {
-	Abi      *abi.ABI `json:"abi"`
-	Bytecode string
+	Abi            *abi.ABI `json:"abi"`
+	Bytecode       string
+	LinkReferences linkReferences `json:"linkReferences"`
 }
 
 func decodeArtifact(data []byte) (*artifact, error) {
@@ -36,7 +40,7 @@ func decodeArtifact(data []byte) (*artifact, error) {
 	// try to decode with hardhat artifact format
 	var hArtifact artifactHardhat
 	if err := json.Unmarshal(data, &hArtifact); err == nil {
-		return &artifact{Abi: hArtifact.Abi, Bytecode: bytecode{Object: hArtifact.Bytecode}}, nil
+		return &artifact{Abi: hArtifact.Abi, Bytecode: bytecode{Object: hArtifact.Bytecode, LinkReferences: hArtifact.LinkReferences}}, nil
 	}
 
 	return nil, fmt.Errorf("unknown artifact format: %s", string(data))
@@ -181,3 +185,88 @@ func parseEtherValue(i string) (*big.Int, error) {
 	}
 	return num, nil
 }
+
+var (
+	// errorSelector is the selector of the Error(string) revert
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+	// panicSelector is the selector of the Panic(uint256) revert
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+
+	panicType = abi.MustNewType("tuple(uint256)")
+)
+
+// panicCodes are the descriptions of the solidity panic codes
+var panicCodes = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to zero-initialized function",
+}
+
+// decodeRevertReason decodes the data returned by a reverted call. It
+// understands the Error(string) and Panic(uint256) reverts and any custom
+// error defined in the (optional) abi of the contract. Unknown data is
+// returned as hex.
+func decodeRevertReason(data []byte, contractAbi *abi.ABI) string {
+	if len(data) == 0 {
+		return ""
+	}
+	if len(data) < 4 {
+		return "0x" + hex.EncodeToString(data)
+	}
+
+	selector, args := data[:4], data[4:]
+
+	if bytes.Equal(selector, errorSelector) {
+		if reason, err := abi.UnpackRevertError(data); err == nil {
+			return reason
+		}
+	}
+	if bytes.Equal(selector, panicSelector) {
+		if val, err := panicType.Decode(args); err == nil {
+			code := val.(map[string]interface{})["0"].(*big.Int)
+			desc, ok := panicCodes[code.Uint64()]
+			if !ok || !code.IsUint64() {
+				desc = "unknown panic"
+			}
+			return fmt.Sprintf("panic 0x%x (%s)", code, desc)
+		}
+	}
+	if contractAbi != nil {
+		for _, customErr := range contractAbi.Errors {
+			// the selector of an error is computed as the one of an event
+			id := abi.NewEventFromType(customErr.Name, customErr.Inputs).ID()
+			if !bytes.Equal(selector, id[:4]) {
+				continue
+			}
+			val, err := customErr.Inputs.Decode(args)
+			if err != nil {
+				break
+			}
+			return customErr.Name + formatErrorArgs(customErr.Inputs, val)
+		}
+	}
+	return "0x" + hex.EncodeToString(data)
+}
+
+// formatErrorArgs formats in order the decoded arguments of a custom error.
+func formatErrorArgs(typ *abi.Type, val interface{}) string {
+	values, _ := val.(map[string]interface{})
+
+	args := []string{}
+	for indx, elem := range typ.TupleElems() {
+		name := elem.Name
+		if name == "" {
+			name = strconv.Itoa(indx)
+		}
+		args = append(args, fmt.Sprint(values[name]))
+	}
+	return "(" + strings.Join(args, ", ") + ")"
+}
@@ -1,10 +1,12 @@
 package ethereum
 
 import (
+	"encoding/hex"
 	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo/abi"
 )
 
 func TestUtils_DecodeArtifact(t *testing.T) {
@@ -98,3 +100,46 @@ func TestParseEther(t *testing.T) {
 		require.Equal(t, val, c.res)
 	}
 }
+
+func TestDecodeRevertReason(t *testing.T) {
+	contractAbi, err := abi.NewABI(`[
+		{"type": "error", "name": "Unauthorized", "inputs": [{"name": "caller", "type": "address"}, {"name": "", "type": "uint256"}]}
+	]`)
+	require.NoError(t, err)
+
+	cases := []struct {
+		data   string
+		reason string
+	}{
+		{
+			// Error("not owner")
+			"08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000096e6f74206f776e65720000000000000000000000000000000000000000000000",
+			"not owner",
+		},
+		{
+			// Panic(0x11)
+			"4e487b710000000000000000000000000000000000000000000000000000000000000011",
+			"panic 0x11 (arithmetic overflow or underflow)",
+		},
+		{
+			// Unauthorized(0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5, 10)
+			"da47202300000000000000000000000095222290dd7278aa3ddd389cc1e1d165cc4bafe5000000000000000000000000000000000000000000000000000000000000000a",
+			"Unauthorized(0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5, 10)",
+		},
+		{
+			// unknown selector
+			"aabbccdd",
+			"0xaabbccdd",
+		},
+		{
+			"",
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		data, err := hex.DecodeString(c.data)
+		require.NoError(t, err)
+		require.Equal(t, c.reason, decodeRevertReason(data, contractAbi))
+	}
+}
@@ -0,0 +1,4 @@
+// The first 10 accounts of the mnemonic
+data "ethereum_accounts" "accounts" {
+  mnemonic = "test test test test test test test test test test test junk"
+}
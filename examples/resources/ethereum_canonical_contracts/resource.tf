@@ -0,0 +1,18 @@
+data "ethereum_eoa" "account" {
+  mnemonic = "test test test test test test test test test test test junk"
+}
+
+// Deploy the canonical contracts that are missing in a development node
+resource "ethereum_canonical_contracts" "devnet" {
+  signer = data.ethereum_eoa.account.signer
+}
+
+// In other chains, the published pre-signed transactions are required
+resource "ethereum_canonical_contracts" "testnet" {
+  signer = data.ethereum_eoa.account.signer
+
+  transactions = {
+    multicall3 = file("multicall3.tx")
+    createx    = file("createx.tx")
+  }
+}
@@ -0,0 +1,5 @@
+# Import a wallet with its hex encoded private key
+terraform import ethereum_eoa.account ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80
+
+# Import a wallet with its mnemonic
+terraform import ethereum_eoa.account "test test test test test test test test test test test junk"
@@ -1,2 +1,7 @@
 resource "ethereum_eoa" "account" {
 }
+
+// Wallet with a generated 24 words mnemonic
+resource "ethereum_eoa" "wallet" {
+  mnemonic_words = 24
+}
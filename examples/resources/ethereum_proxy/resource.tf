@@ -0,0 +1,18 @@
+data "ethereum_eoa" "account" {
+  mnemonic = "test test test test test test test test test test test junk"
+}
+
+resource "ethereum_contract_deployment" "implementation" {
+  signer   = data.ethereum_eoa.account.signer
+  artifact = "./out:Counter"
+}
+
+// Deploy an UUPS proxy for the implementation and initialize it
+resource "ethereum_proxy" "proxy" {
+  signer   = data.ethereum_eoa.account.signer
+  artifact = "./out:ERC1967Proxy"
+
+  implementation    = ethereum_contract_deployment.implementation.contract_address
+  initializer       = "initialize(address)"
+  initializer_input = [data.ethereum_eoa.account.address]
+}
@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: UNLICENSED
+pragma solidity ^0.8.4;
+
+library Math {
+    function add(uint256 a, uint256 b) external pure returns (uint256) {
+        return a + b;
+    }
+}
+
+contract Calculator {
+    function add(uint256 a, uint256 b) public pure returns (uint256) {
+        return Math.add(a, b);
+    }
+}
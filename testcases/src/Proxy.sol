@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: UNLICENSED
+pragma solidity ^0.8.4;
+
+// Minimal EIP-1967 proxies with the same constructors and upgrade
+// functions as the OpenZeppelin ERC1967Proxy and TransparentUpgradeableProxy.
+abstract contract ERC1967Upgrade {
+    bytes32 internal constant IMPLEMENTATION_SLOT = 0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc;
+    bytes32 internal constant ADMIN_SLOT = 0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103;
+
+    function _setSlot(bytes32 slot, address value) internal {
+        assembly {
+            sstore(slot, value)
+        }
+    }
+
+    function _getSlot(bytes32 slot) internal view returns (address value) {
+        assembly {
+            value := sload(slot)
+        }
+    }
+
+    function _upgradeToAndCall(address implementation, bytes memory data) internal {
+        _setSlot(IMPLEMENTATION_SLOT, implementation);
+        if (data.length > 0) {
+            (bool ok, ) = implementation.delegatecall(data);
+            require(ok, "call failed");
+        }
+    }
+
+    function _delegate() internal {
+        address implementation = _getSlot(IMPLEMENTATION_SLOT);
+        assembly {
+            calldatacopy(0, 0, calldatasize())
+            let result := delegatecall(gas(), implementation, 0, calldatasize(), 0, 0)
+            returndatacopy(0, 0, returndatasize())
+            switch result
+            case 0 {
+                revert(0, returndatasize())
+            }
+            default {
+                return(0, returndatasize())
+            }
+        }
+    }
+}
+
+contract ERC1967Proxy is ERC1967Upgrade {
+    constructor(address implementation, bytes memory data) payable {
+        _upgradeToAndCall(implementation, data);
+    }
+
+    fallback() external payable {
+        _delegate();
+    }
+}
+
+contract TransparentUpgradeableProxy is ERC1967Upgrade {
+    constructor(address logic, address admin, bytes memory data) payable {
+        _setSlot(ADMIN_SLOT, admin);
+        _upgradeToAndCall(logic, data);
+    }
+
+    fallback() external payable {
+        if (msg.sender != _getSlot(ADMIN_SLOT)) {
+            _delegate();
+            return;
+        }
+        require(msg.sig == bytes4(keccak256("upgradeToAndCall(address,bytes)")), "admin cannot fallback to proxy target");
+        (address implementation, bytes memory data) = abi.decode(msg.data[4:], (address, bytes));
+        _upgradeToAndCall(implementation, data);
+    }
+}
+
+// CounterV1 is an UUPS implementation that anyone can upgrade.
+contract CounterV1 is ERC1967Upgrade {
+    uint256 public value;
+
+    function initialize(uint256 _value) public {
+        value = _value;
+    }
+
+    function upgradeToAndCall(address implementation, bytes memory data) public payable {
+        _upgradeToAndCall(implementation, data);
+    }
+
+    function version() public pure virtual returns (uint256) {
+        return 1;
+    }
+}
+
+contract CounterV2 is CounterV1 {
+    function increment() public {
+        value += 1;
+    }
+
+    function version() public pure override returns (uint256) {
+        return 2;
+    }
+}
@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: UNLICENSED
+pragma solidity ^0.8.4;
+
+contract Reverts {
+    error Unauthorized(address caller);
+
+    function withReason() public pure {
+        revert("not allowed");
+    }
+
+    function withCustomError() public view {
+        revert Unauthorized(msg.sender);
+    }
+
+    function withPanic(uint256 a) public pure returns (uint256) {
+        return a / 0;
+    }
+}